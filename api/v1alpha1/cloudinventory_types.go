@@ -23,6 +23,16 @@ type CloudInventorySpec struct {
 	// Kubernetes-specific inventory options (required if Mode == "kubernetes")
 	// +optional
 	Kubernetes *KubernetesInventorySpec `json:"kubernetes,omitempty"`
+
+	// Schedule is an optional cron expression for running the inventory on its own.
+	// When empty, scans only run when triggered by a WorkPackages inventoryRef.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// TimeZone is the IANA time zone the schedule is evaluated in, e.g. "Europe/Berlin".
+	// Defaults to the operator's local time (UTC in most clusters).
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
 }
 
 // AWSInventorySpec holds configuration for AWS inventory scanning
@@ -61,6 +71,12 @@ type ContainerImageInfo struct {
 type CloudInventoryStatus struct {
 	// +optional
 	LastRunTime metav1.Time `json:"lastRunTime,omitempty"`
+	// NextRunTime is the next scheduled run, shown in UTC like all status timestamps
+	// +optional
+	NextRunTime *metav1.Time `json:"nextRunTime,omitempty"`
+	// TimeZone is the time zone the schedule is evaluated in, spec.timeZone or the operator's local zone
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
 	// +optional
 	LastFailedTime *metav1.Time `json:"lastFailedTime,omitempty"`
 	LastRunSuccess bool         `json:"lastRunSuccess,omitempty"`
@@ -78,6 +94,15 @@ type CloudInventoryStatus struct {
 	// // AWS specific inventory results
 	// EC2 []EC2InstanceInfo `json:"ec2,omitempty"`
 	// RDS []RDSInstanceInfo `json:"rds,omitempty"`
+
+	// ObservedGeneration is the metadata.generation the status was computed for
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions describe the current state of the resource
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
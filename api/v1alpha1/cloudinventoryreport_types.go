@@ -110,6 +110,15 @@ type CloudInventoryReportStatus struct {
 	// Kubernetes
 	ContainerImages []ContainerImageInfo `json:"containerImages,omitempty" yaml:"containerImages,omitempty"`
 	Summary         map[string]int       `json:"summary,omitempty" yaml:"summary,omitempty"`
+
+	// ObservedGeneration is the metadata.generation the status was computed for
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions describe the current state of the resource
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
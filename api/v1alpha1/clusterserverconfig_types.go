@@ -0,0 +1,56 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterServerConfigSpec is a ServerConfigSpec shared with WorkPackages of selected namespaces
+type ClusterServerConfigSpec struct {
+	ServerConfigSpec `json:",inline"`
+	// CredentialsNamespace is the namespace of the Secrets and ConfigMaps the spec refers to
+	CredentialsNamespace string `json:"credentialsNamespace"`
+	// NamespaceSelector selects the namespaces whose WorkPackages may use this config. An
+	// empty selector allows every namespace; no namespace may use it when unset.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+
+// ClusterServerConfig is a cluster-wide OpenProject connection
+type ClusterServerConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterServerConfigSpec `json:"spec,omitempty"`
+	Status ServerConfigStatus      `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+type ClusterServerConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterServerConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterServerConfig{}, &ClusterServerConfigList{})
+}
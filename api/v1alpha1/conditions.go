@@ -0,0 +1,79 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// Condition types reported in status.conditions
+const (
+	// ConditionReady is True when the resource is configured correctly and working
+	ConditionReady = "Ready"
+	// ConditionServerReachable is True when the OpenProject server answered the last request
+	ConditionServerReachable = "ServerReachable"
+	// ConditionCredentialsValid is True when the API credentials were loaded and accepted
+	ConditionCredentialsValid = "CredentialsValid"
+	// ConditionInventoryReady is True when an inventory report is available
+	ConditionInventoryReady = "InventoryReady"
+	// ConditionLastRunSucceeded reflects the outcome of the last run
+	ConditionLastRunSucceeded = "LastRunSucceeded"
+	// ConditionDegraded is True when the resource works but its last operation failed
+	ConditionDegraded = "Degraded"
+	// ConditionPrincipalsResolved is True when the assignee, responsible and watchers were found
+	ConditionPrincipalsResolved = "PrincipalsResolved"
+	// ConditionCustomFieldsValid is True when every custom field matched the project's schema
+	ConditionCustomFieldsValid = "CustomFieldsValid"
+	// ConditionPayloadValid is True when OpenProject accepted the payload of the last dry run
+	ConditionPayloadValid = "PayloadValid"
+)
+
+// Condition reasons
+const (
+	ReasonScheduled              = "Scheduled"
+	ReasonSuspended              = "Suspended"
+	ReasonInvalidSpec            = "InvalidSpec"
+	ReasonTicketCreated          = "TicketCreated"
+	ReasonTicketExists           = "TicketExists"
+	ReasonTicketCreationFailed   = "TicketCreationFailed"
+	ReasonCommentAdded           = "CommentAdded"
+	ReasonValidated              = "Validated"
+	ReasonRequestFailed          = "RequestFailed"
+	ReasonResponseReceived       = "ResponseReceived"
+	ReasonUnauthorized           = "Unauthorized"
+	ReasonServerConfigNotFound   = "ServerConfigNotFound"
+	ReasonServerConfigNotGranted = "ServerConfigNotGranted"
+	ReasonSecretNotFound         = "SecretNotFound"
+	ReasonCredentialsLoaded      = "CredentialsLoaded"
+	ReasonInventoryCompleted     = "InventoryCompleted"
+	ReasonInventoryFailed        = "InventoryFailed"
+	ReasonInventoryUnavailable   = "InventoryUnavailable"
+	ReasonReportPopulated        = "ReportPopulated"
+	ReasonAsExpected             = "AsExpected"
+	ReasonRunsSkipped            = "RunsSkipped"
+	ReasonReportCreated          = "ReportCreated"
+	ReasonPreviousTicketFailed   = "PreviousTicketPolicyFailed"
+	ReasonReferenceNotResolved   = "ReferenceNotResolved"
+	ReasonAttachmentFailed       = "AttachmentFailed"
+	ReasonPrincipalsResolved     = "PrincipalsResolved"
+	ReasonPrincipalNotFound      = "PrincipalNotFound"
+	ReasonWatcherFailed          = "WatcherFailed"
+	ReasonCustomFieldsResolved   = "CustomFieldsResolved"
+	ReasonRelationFailed         = "RelationFailed"
+	ReasonFindingFailed          = "FindingFailed"
+	ReasonInvalidCustomField     = "InvalidCustomField"
+	ReasonDryRun                 = "DryRun"
+	ReasonPayloadAccepted        = "PayloadAccepted"
+	ReasonPayloadRejected        = "PayloadRejected"
+	ReasonFormUnavailable        = "FormUnavailable"
+)
@@ -25,15 +25,112 @@ import (
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // ServerConfigSpec defines the desired state of ServerConfig
+// +kubebuilder:validation:XValidation:rule="has(self.apiKeySecretRef) || has(self.auth)",message="one of apiKeySecretRef or auth is required"
 type ServerConfigSpec struct {
-	Server          string                   `json:"server"`
-	APIKeySecretRef corev1.SecretKeySelector `json:"apiKeySecretRef"`
+	Server string `json:"server"`
+	// APIKeySecretRef is the Secret key holding the API key, raw or base64 encoded.
+	// Deprecated: use auth.apiKey, which takes precedence and has an explicit encoding.
+	// +optional
+	APIKeySecretRef corev1.SecretKeySelector `json:"apiKeySecretRef,omitempty"`
+	// Auth selects how the operator authenticates to the server
+	// +optional
+	Auth *ServerAuth `json:"auth,omitempty"`
+	// TLS configures the trusted CAs and client certificate for the server
+	// +optional
+	TLS *ServerTLS `json:"tls,omitempty"`
+	// ProxyURL is the HTTP(S) proxy requests are sent through; the proxy environment
+	// variables of the operator are used when empty
+	// +kubebuilder:validation:Pattern=`^(http|https|socks5)://`
+	// +optional
+	ProxyURL string `json:"proxyURL,omitempty"`
+	// Timeout bounds each request to the server; REQUEST_TIMEOUT when unset
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// ServerTLS configures TLS for requests to the server
+type ServerTLS struct {
+	// CABundle adds PEM encoded CA certificates to the system roots
+	// +optional
+	CABundle *CABundleSource `json:"caBundle,omitempty"`
+	// ClientCertSecretRef is a kubernetes.io/tls Secret with the client certificate for mTLS
+	// +optional
+	ClientCertSecretRef *corev1.LocalObjectReference `json:"clientCertSecretRef,omitempty"`
+	// InsecureSkipVerify disables server certificate verification; only for testing
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// CABundleSource is a CA bundle in a ConfigMap or Secret key
+// +kubebuilder:validation:XValidation:rule="has(self.configMapRef) != has(self.secretRef)",message="exactly one of configMapRef or secretRef is required"
+type CABundleSource struct {
+	// +optional
+	ConfigMapRef *corev1.ConfigMapKeySelector `json:"configMapRef,omitempty"`
+	// +optional
+	SecretRef *corev1.SecretKeySelector `json:"secretRef,omitempty"`
+}
+
+// ServerAuth configures authentication; exactly one method is set
+// +kubebuilder:validation:XValidation:rule="has(self.apiKey) != has(self.oauth2ClientCredentials)",message="exactly one of apiKey or oauth2ClientCredentials is required"
+type ServerAuth struct {
+	// APIKey authenticates with a user's API key using Basic auth
+	// +optional
+	APIKey *APIKeyAuth `json:"apiKey,omitempty"`
+	// OAuth2ClientCredentials authenticates with bearer tokens from the client credentials flow
+	// +optional
+	OAuth2ClientCredentials *OAuth2ClientCredentialsAuth `json:"oauth2ClientCredentials,omitempty"`
+}
+
+// APIKeyAuth is an API key stored in a Secret
+type APIKeyAuth struct {
+	// SecretRef is the Secret key holding the API key
+	SecretRef corev1.SecretKeySelector `json:"secretRef"`
+	// Encoding is raw when the Secret holds the key itself, or base64 when it holds the
+	// base64 encoded key
+	// +kubebuilder:validation:Enum=raw;base64
+	// +kubebuilder:default=raw
+	// +optional
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// OAuth2ClientCredentialsAuth is an OAuth2 application using the client credentials grant
+type OAuth2ClientCredentialsAuth struct {
+	// TokenURL is the token endpoint, usually <server>/oauth/token
+	TokenURL string `json:"tokenURL"`
+	// ClientIDSecretRef is the Secret key holding the client ID
+	ClientIDSecretRef corev1.SecretKeySelector `json:"clientIDSecretRef"`
+	// ClientSecretSecretRef is the Secret key holding the client secret
+	ClientSecretSecretRef corev1.SecretKeySelector `json:"clientSecretSecretRef"`
+	// Scopes are requested with each token
+	// +kubebuilder:default={api_v3}
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
 }
 
 // ServerConfigStatus defines the observed state of ServerConfig
 type ServerConfigStatus struct {
 	Validated bool   `json:"validated,omitempty"`
 	Message   string `json:"message,omitempty"`
+	// LastValidated is when a check of the server and credentials last changed the status;
+	// checks with an unchanged result are not written
+	// +optional
+	LastValidated *metav1.Time `json:"lastValidated,omitempty"`
+	// InstanceName and ServerVersion are reported by the API root
+	// +optional
+	InstanceName string `json:"instanceName,omitempty"`
+	// +optional
+	ServerVersion string `json:"serverVersion,omitempty"`
+	// AuthenticatedUser is the login of the user the credentials belong to
+	// +optional
+	AuthenticatedUser string `json:"authenticatedUser,omitempty"`
+	// ObservedGeneration is the metadata.generation the status was computed for
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions describe the current state of the resource
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,57 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServerConfigGrantSpec allows WorkPackages of other namespaces to use ServerConfigs of the
+// grant's namespace
+// +kubebuilder:validation:XValidation:rule="has(self.namespaces) || has(self.namespaceSelector)",message="one of namespaces or namespaceSelector is required"
+type ServerConfigGrantSpec struct {
+	// ServerConfigNames are the ServerConfigs granted; all ServerConfigs of the namespace when empty
+	// +optional
+	ServerConfigNames []string `json:"serverConfigNames,omitempty"`
+	// Namespaces are granted by name
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// NamespaceSelector grants namespaces by label; an empty selector grants every namespace
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ServerConfigGrant allows cross-namespace references to ServerConfigs of its namespace
+type ServerConfigGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ServerConfigGrantSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+type ServerConfigGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServerConfigGrant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ServerConfigGrant{}, &ServerConfigGrantList{})
+}
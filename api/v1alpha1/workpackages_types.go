@@ -27,9 +27,30 @@ import (
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// RunNowAnnotation triggers an immediate WorkPackages run when set to a value not yet handled,
+// e.g. a timestamp. The handled value is recorded in status.lastHandledRunNow.
+const RunNowAnnotation = "openproject.org/run-now"
+
+// ServerConfig kinds a WorkPackages can refer to
+const (
+	ServerConfigKind        = "ServerConfig"
+	ClusterServerConfigKind = "ClusterServerConfig"
+)
+
+// ServerConfigRef refers to a ServerConfig, in the same or a granting namespace, or to a
+// ClusterServerConfig
+// +kubebuilder:validation:XValidation:rule="!has(self.namespace) || !has(self.kind) || self.kind == 'ServerConfig'",message="namespace cannot be set for a ClusterServerConfig"
 type ServerConfigRef struct {
 	Name string `json:"name"`
-	// Optional: Namespace string `json:"namespace,omitempty"`
+	// Kind is ServerConfig or ClusterServerConfig
+	// +kubebuilder:validation:Enum=ServerConfig;ClusterServerConfig
+	// +kubebuilder:default=ServerConfig
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// Namespace of the ServerConfig, defaulting to the namespace of the WorkPackages. A
+	// ServerConfigGrant in that namespace must allow this namespace to use it.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 }
 
 // JSON represents an arbitrary JSON value
@@ -59,6 +80,9 @@ func (m *JSON) UnmarshalJSON(data []byte) error {
 }
 
 // WorkPackagesSpec defines the desired state of WorkPackages
+// +kubebuilder:validation:XValidation:rule="has(self.projectID) || has(self.projectRef)",message="one of projectID or projectRef is required"
+// +kubebuilder:validation:XValidation:rule="has(self.typeID) || has(self.typeRef)",message="one of typeID or typeRef is required"
+// +kubebuilder:validation:XValidation:rule="!has(self.mode) || self.mode != 'comment' || has(self.targetWorkPackageID) || has(self.targetWorkPackageRef)",message="mode comment requires targetWorkPackageID or targetWorkPackageRef"
 type WorkPackagesSpec struct {
 	// +kubebuilder:validation:Required
 	// Subject is the title of the ticket
@@ -66,29 +90,307 @@ type WorkPackagesSpec struct {
 	// Description is the markdown content for the ticket
 	Description string `json:"description"`
 
+	// DescriptionTemplate renders the description as a Go template like the subject; without it
+	// the description is used verbatim, so literal {{ }} need no escaping
+	// +optional
+	DescriptionTemplate bool `json:"descriptionTemplate,omitempty"`
+
 	// ProjectID is the numeric ID of the OpenProject project
-	ProjectID int `json:"projectID"`
+	// +optional
+	ProjectID int `json:"projectID,omitempty"`
+
+	// ProjectRef is the identifier or name of the project, used when ProjectID is not set
+	// +optional
+	ProjectRef string `json:"projectRef,omitempty"`
 
 	// TypeID is the numeric ID of the work package type
-	TypeID int `json:"typeID"`
+	// +optional
+	TypeID int `json:"typeID,omitempty"`
+
+	// TypeRef is the name of the work package type, used when TypeID is not set
+	// +optional
+	TypeRef string `json:"typeRef,omitempty"`
+
+	// Assignee is the login, email or group name the ticket is assigned to
+	// +optional
+	Assignee string `json:"assignee,omitempty"`
+
+	// Responsible is the login, email or group name accountable for the ticket
+	// +optional
+	Responsible string `json:"responsible,omitempty"`
+
+	// Watchers are the logins or emails of users added as watchers after creation
+	// +optional
+	Watchers []string `json:"watchers,omitempty"`
+
+	// Mode is create to open a new ticket per run, or comment to post each run as a comment
+	// on one long-lived ticket
+	// +kubebuilder:validation:Enum=create;comment
+	// +kubebuilder:default=create
+	// +optional
+	Mode string `json:"mode,omitempty"`
+
+	// TargetWorkPackageID is the ticket commented on in comment mode
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetWorkPackageID int `json:"targetWorkPackageID,omitempty"`
+
+	// TargetWorkPackageRef is the subject of an open ticket in the project, used in comment
+	// mode when TargetWorkPackageID is not set
+	// +optional
+	TargetWorkPackageRef string `json:"targetWorkPackageRef,omitempty"`
+
+	// DryRun renders each run into the ConfigMap <name>-dry-run and checks the payload with
+	// OpenProject's form endpoint instead of creating a ticket or comment
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
 
 	// EpicID is the parent work package ID (optional)
 	// +optional
 	EpicID int `json:"epicID,omitempty"`
 
+	// Relations are created from each new ticket to other work packages
+	// +optional
+	Relations []WorkPackageRelation `json:"relations,omitempty"`
+
 	// Schedule is a cron expression for when to create the ticket
 	Schedule string `json:"schedule"`
 
+	// TimeZone is the IANA time zone the schedule is evaluated in, e.g. "Europe/Berlin".
+	// Defaults to the operator's local time (UTC in most clusters).
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// StartingDeadlineSeconds is how late a scheduled run may still start; older runs count as missed
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+
+	// MissedRunPolicy decides how runs missed while the operator was down are handled:
+	// skip them all, run once for the latest one, or backfill every missed run. Under skip a
+	// slot is missed once it is older than startingDeadlineSeconds, or 5 minutes when unset.
+	// +kubebuilder:validation:Enum=skip;runOnce;backfillAll
+	// +kubebuilder:default=runOnce
+	// +optional
+	MissedRunPolicy string `json:"missedRunPolicy,omitempty"`
+
+	// ConcurrencyPolicy decides what happens when a run is due while another has not completed:
+	// Forbid finishes the active run first, Replace abandons it for the new one
+	// +kubebuilder:validation:Enum=Forbid;Replace
+	// +kubebuilder:default=Forbid
+	// +optional
+	ConcurrencyPolicy string `json:"concurrencyPolicy,omitempty"`
+
+	// Suspend pauses scheduled runs; the run-now annotation still works while suspended
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
 	// ServerConfigRef is a reference to the OpenProject server configuration
-	ServerConfigRef corev1.LocalObjectReference `json:"serverConfigRef"`
+	ServerConfigRef ServerConfigRef `json:"serverConfigRef"`
 
 	// AdditionalFields contains extra fields to include in the work package
 	// +optional
 	AdditionalFields JSON `json:"additionalFields,omitempty"`
 
+	// CustomFields maps custom field names, as shown in OpenProject, to values. List, user and
+	// version fields take labels, names or logins; multi-value fields take a comma separated list.
+	// +optional
+	CustomFields map[string]string `json:"customFields,omitempty"`
+
 	// InventoryRef is an optional reference to a CloudInventory to run/report
 	// +optional
 	InventoryRef *corev1.LocalObjectReference `json:"inventoryRef,omitempty"`
+
+	// InventoryAttachment uploads the inventory as files instead of inlining it in the description
+	// +optional
+	InventoryAttachment *InventoryAttachmentSpec `json:"inventoryAttachment,omitempty"`
+
+	// Findings creates a child work package under the run's ticket for each actionable inventory item
+	// +optional
+	Findings *FindingsSpec `json:"findings,omitempty"`
+
+	// PreviousTicketPolicy controls what happens to the ticket created by the previous run
+	// +kubebuilder:validation:Enum=leave;close;relate;commentAndClose
+	// +kubebuilder:default=leave
+	// +optional
+	PreviousTicketPolicy string `json:"previousTicketPolicy,omitempty"`
+
+	// PreviousTicketStatusID is the status applied to the previous ticket by the close policies
+	// +optional
+	PreviousTicketStatusID int `json:"previousTicketStatusID,omitempty"`
+
+	// PreviousTicketComment is an optional comment added to the previous ticket
+	// +optional
+	PreviousTicketComment string `json:"previousTicketComment,omitempty"`
+
+	// HistoryLimit is how many runs are kept in status.history
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=10
+	// +optional
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+}
+
+// WorkPackageRelation relates a created ticket to another work package
+// +kubebuilder:validation:XValidation:rule="has(self.toID) != has(self.selector)",message="exactly one of toID or selector is required"
+type WorkPackageRelation struct {
+	// Type is the relation from the created ticket, e.g. follows for "follows the previous ticket"
+	// +kubebuilder:validation:Enum=relates;duplicates;duplicated;blocks;blocked;precedes;follows;includes;partof;requires;required
+	Type string `json:"type"`
+
+	// ToID is the ID of the related work package
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ToID int `json:"toID,omitempty"`
+
+	// Selector picks the related work package; previousRun is the ticket of the previous run
+	// +kubebuilder:validation:Enum=previousRun
+	// +optional
+	Selector string `json:"selector,omitempty"`
+}
+
+// WorkPackageRelationResult is the outcome of creating one relation
+type WorkPackageRelationResult struct {
+	// Type is the relation type
+	Type string `json:"type"`
+	// ToID is the related work package
+	// +optional
+	ToID int `json:"toID,omitempty"`
+	// RelationID is the created relation
+	// +optional
+	RelationID int `json:"relationID,omitempty"`
+	// Error is set when the relation could not be created
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// FindingsSpec controls the child work packages created for inventory findings
+type FindingsSpec struct {
+	// Enabled turns on child work packages for findings
+	Enabled bool `json:"enabled"`
+
+	// Rules selects the findings to report; all rules are used when empty
+	// +optional
+	Rules []FindingRule `json:"rules,omitempty"`
+
+	// Subject is a template for the child subject; .Finding describes the item
+	// +kubebuilder:default="{{ .Finding.Title }}"
+	// +optional
+	Subject string `json:"subject,omitempty"`
+
+	// TypeID is the work package type of the children; defaults to the type of the run's ticket
+	// +optional
+	TypeID int `json:"typeID,omitempty"`
+
+	// Limit caps the children created per run
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=50
+	// +optional
+	Limit *int32 `json:"limit,omitempty"`
+}
+
+// FindingRule is a check that turns inventory items into findings
+// +kubebuilder:validation:Enum=publicS3Bucket;publicRDSInstance;unassociatedEIP
+type FindingRule string
+
+const (
+	// FindingPublicS3Bucket matches S3 buckets without BlockAllPublicAccess
+	FindingPublicS3Bucket FindingRule = "publicS3Bucket"
+	// FindingPublicRDSInstance matches publicly accessible RDS instances
+	FindingPublicRDSInstance FindingRule = "publicRDSInstance"
+	// FindingUnassociatedEIP matches Elastic IPs not attached to an instance or interface
+	FindingUnassociatedEIP FindingRule = "unassociatedEIP"
+)
+
+// InventoryAttachmentSpec controls how the inventory report is attached to created tickets
+type InventoryAttachmentSpec struct {
+	// Enabled attaches the report and keeps only the summary and links in the description
+	Enabled bool `json:"enabled"`
+
+	// Formats are the files to attach: csv (one file per service) and json (the full report)
+	// +kubebuilder:default={csv,json}
+	// +optional
+	Formats []InventoryAttachmentFormat `json:"formats,omitempty"`
+}
+
+// InventoryAttachmentFormat is a file format for attached inventory exports
+// +kubebuilder:validation:Enum=csv;json
+type InventoryAttachmentFormat string
+
+const (
+	InventoryAttachmentCSV  InventoryAttachmentFormat = "csv"
+	InventoryAttachmentJSON InventoryAttachmentFormat = "json"
+)
+
+// WorkPackageRun identifies a scheduled run that has started but not completed
+type WorkPackageRun struct {
+	RunKey        string      `json:"runKey"`
+	ScheduledTime metav1.Time `json:"scheduledTime"`
+	StartTime     metav1.Time `json:"startTime"`
+	// Retries counts the reconciles that tried the run again after a transient failure
+	// +optional
+	Retries int32 `json:"retries,omitempty"`
+}
+
+// WorkPackageRunRecord is a completed run kept in the WorkPackages history
+type WorkPackageRunRecord struct {
+	// RunKey identifies the run on the ticket
+	RunKey string `json:"runKey"`
+	// ScheduledTime is the schedule slot, or the trigger time of a manual run
+	ScheduledTime metav1.Time `json:"scheduledTime"`
+	// StartTime is when the run actually started
+	StartTime metav1.Time `json:"startTime"`
+	// Duration is how long the run took
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// TicketID and TicketURL point at the ticket the run created or found
+	// +optional
+	TicketID string `json:"ticketID,omitempty"`
+	// +optional
+	TicketURL string `json:"ticketURL,omitempty"`
+	// CommentID is the activity posted in comment mode
+	// +optional
+	CommentID string `json:"commentID,omitempty"`
+	// HTTPStatus is the OpenProject response status of the create request
+	// +optional
+	HTTPStatus int `json:"httpStatus,omitempty"`
+	// Attempts is how many times the create request was sent, including retries
+	// +optional
+	Attempts int `json:"attempts,omitempty"`
+	// ErrorCategory classifies the final error of a failed run: Network, RateLimited,
+	// ServerError, Unauthorized, NotFound, Validation or ClientError
+	// +optional
+	ErrorCategory string `json:"errorCategory,omitempty"`
+	// InventoryReport is the CloudInventoryReport included in the ticket
+	// +optional
+	InventoryReport string `json:"inventoryReport,omitempty"`
+	// Relations are the relations created from the ticket
+	// +optional
+	Relations []WorkPackageRelationResult `json:"relations,omitempty"`
+	// Findings is the number of child work packages created for inventory findings
+	// +optional
+	Findings int `json:"findings,omitempty"`
+	// Outcome is Created, Commented, Existing, DryRun or Failed
+	Outcome string `json:"outcome"`
+	// Message describes the outcome
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// DryRunResult is the outcome of the last dry run
+type DryRunResult struct {
+	// ConfigMapName holds payload.json, the request body, and description.md, the rendered markdown
+	ConfigMapName string `json:"configMapName"`
+	// RenderTime is when the payload was rendered
+	RenderTime metav1.Time `json:"renderTime"`
+	// Valid is whether OpenProject accepted the payload; unset when it could not be checked
+	// +optional
+	Valid *bool `json:"valid,omitempty"`
+	// ValidationErrors are the problems OpenProject found, as attribute: message
+	// +optional
+	ValidationErrors []string `json:"validationErrors,omitempty"`
+	// Message describes the validation result
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // WorkPackagesStatus defines the observed state of WorkPackages
@@ -97,8 +399,49 @@ type WorkPackagesStatus struct {
 	Status      string       `json:"status,omitempty"`
 	Message     string       `json:"message,omitempty"`
 	LastRunTime *metav1.Time `json:"lastRunTime,omitempty"`
+	// NextRunTime is the next schedule slot, shown in UTC like all status timestamps
 	NextRunTime *metav1.Time `json:"nextRunTime,omitempty"`
-	TicketID    string       `json:"ticketID,omitempty"`
+	// TimeZone is the time zone the schedule is evaluated in, spec.timeZone or the operator's local zone
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+	TicketID string `json:"ticketID,omitempty"`
+	// LastRunKey identifies the scheduled run that produced TicketID
+	LastRunKey string `json:"lastRunKey,omitempty"`
+	// CommentTicketID is the long-lived ticket commented on in comment mode. It is kept apart
+	// from TicketID so that the previous ticket policy never closes it.
+	// +optional
+	CommentTicketID string `json:"commentTicketID,omitempty"`
+	// CommentID is the activity posted on CommentTicketID by the last run in comment mode
+	// +optional
+	CommentID string `json:"commentID,omitempty"`
+	// LastScheduleTime is the last schedule slot that was run or skipped
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// ActiveRun is set while a run is in progress or waiting to be retried
+	// +optional
+	ActiveRun *WorkPackageRun `json:"activeRun,omitempty"`
+	// ResolvedProjectID and ResolvedTypeID are the IDs the ticket is created with
+	// +optional
+	ResolvedProjectID int `json:"resolvedProjectID,omitempty"`
+	// +optional
+	ResolvedTypeID int `json:"resolvedTypeID,omitempty"`
+	// LastHandledRunNow is the last run-now annotation value that was acted on
+	// +optional
+	LastHandledRunNow string `json:"lastHandledRunNow,omitempty"`
+	// DryRun is the result of the last run while spec.dryRun was set
+	// +optional
+	DryRun *DryRunResult `json:"dryRun,omitempty"`
+	// History lists the most recent runs, newest first, bounded by spec.historyLimit
+	// +optional
+	History []WorkPackageRunRecord `json:"history,omitempty"`
+	// ObservedGeneration is the metadata.generation the status was computed for
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions describe the current state of the resource
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
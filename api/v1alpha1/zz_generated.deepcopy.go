@@ -22,9 +22,26 @@ package v1alpha1
 
 import (
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIKeyAuth) DeepCopyInto(out *APIKeyAuth) {
+	*out = *in
+	in.SecretRef.DeepCopyInto(&out.SecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKeyAuth.
+func (in *APIKeyAuth) DeepCopy() *APIKeyAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(APIKeyAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSInventorySpec) DeepCopyInto(out *AWSInventorySpec) {
 	*out = *in
@@ -50,6 +67,31 @@ func (in *AWSInventorySpec) DeepCopy() *AWSInventorySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CABundleSource) DeepCopyInto(out *CABundleSource) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CABundleSource.
+func (in *CABundleSource) DeepCopy() *CABundleSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CABundleSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudInventory) DeepCopyInto(out *CloudInventory) {
 	*out = *in
@@ -254,6 +296,13 @@ func (in *CloudInventoryReportStatus) DeepCopyInto(out *CloudInventoryReportStat
 			(*out)[key] = val
 		}
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudInventoryReportStatus.
@@ -295,6 +344,10 @@ func (in *CloudInventorySpec) DeepCopy() *CloudInventorySpec {
 func (in *CloudInventoryStatus) DeepCopyInto(out *CloudInventoryStatus) {
 	*out = *in
 	in.LastRunTime.DeepCopyInto(&out.LastRunTime)
+	if in.NextRunTime != nil {
+		in, out := &in.NextRunTime, &out.NextRunTime
+		*out = (*in).DeepCopy()
+	}
 	if in.LastFailedTime != nil {
 		in, out := &in.LastFailedTime, &out.LastFailedTime
 		*out = (*in).DeepCopy()
@@ -306,6 +359,13 @@ func (in *CloudInventoryStatus) DeepCopyInto(out *CloudInventoryStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudInventoryStatus.
@@ -318,6 +378,86 @@ func (in *CloudInventoryStatus) DeepCopy() *CloudInventoryStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterServerConfig) DeepCopyInto(out *ClusterServerConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterServerConfig.
+func (in *ClusterServerConfig) DeepCopy() *ClusterServerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterServerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterServerConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterServerConfigList) DeepCopyInto(out *ClusterServerConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterServerConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterServerConfigList.
+func (in *ClusterServerConfigList) DeepCopy() *ClusterServerConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterServerConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterServerConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterServerConfigSpec) DeepCopyInto(out *ClusterServerConfigSpec) {
+	*out = *in
+	in.ServerConfigSpec.DeepCopyInto(&out.ServerConfigSpec)
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterServerConfigSpec.
+func (in *ClusterServerConfigSpec) DeepCopy() *ClusterServerConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterServerConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerImageInfo) DeepCopyInto(out *ContainerImageInfo) {
 	*out = *in
@@ -333,6 +473,32 @@ func (in *ContainerImageInfo) DeepCopy() *ContainerImageInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DryRunResult) DeepCopyInto(out *DryRunResult) {
+	*out = *in
+	in.RenderTime.DeepCopyInto(&out.RenderTime)
+	if in.Valid != nil {
+		in, out := &in.Valid, &out.Valid
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ValidationErrors != nil {
+		in, out := &in.ValidationErrors, &out.ValidationErrors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DryRunResult.
+func (in *DryRunResult) DeepCopy() *DryRunResult {
+	if in == nil {
+		return nil
+	}
+	out := new(DryRunResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EC2InstanceInfo) DeepCopyInto(out *EC2InstanceInfo) {
 	*out = *in
@@ -424,6 +590,31 @@ func (in *ELBV2Info) DeepCopy() *ELBV2Info {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FindingsSpec) DeepCopyInto(out *FindingsSpec) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]FindingRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.Limit != nil {
+		in, out := &in.Limit, &out.Limit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FindingsSpec.
+func (in *FindingsSpec) DeepCopy() *FindingsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FindingsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InternetGatewayInfo) DeepCopyInto(out *InternetGatewayInfo) {
 	*out = *in
@@ -451,6 +642,26 @@ func (in *InternetGatewayInfo) DeepCopy() *InternetGatewayInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryAttachmentSpec) DeepCopyInto(out *InventoryAttachmentSpec) {
+	*out = *in
+	if in.Formats != nil {
+		in, out := &in.Formats, &out.Formats
+		*out = make([]InventoryAttachmentFormat, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryAttachmentSpec.
+func (in *InventoryAttachmentSpec) DeepCopy() *InventoryAttachmentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryAttachmentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JSON) DeepCopyInto(out *JSON) {
 	*out = *in
@@ -514,6 +725,28 @@ func (in *NATGatewayInfo) DeepCopy() *NATGatewayInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuth2ClientCredentialsAuth) DeepCopyInto(out *OAuth2ClientCredentialsAuth) {
+	*out = *in
+	in.ClientIDSecretRef.DeepCopyInto(&out.ClientIDSecretRef)
+	in.ClientSecretSecretRef.DeepCopyInto(&out.ClientSecretSecretRef)
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuth2ClientCredentialsAuth.
+func (in *OAuth2ClientCredentialsAuth) DeepCopy() *OAuth2ClientCredentialsAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuth2ClientCredentialsAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RDSInstanceInfo) DeepCopyInto(out *RDSInstanceInfo) {
 	*out = *in
@@ -558,13 +791,38 @@ func (in *S3BucketInfo) DeepCopy() *S3BucketInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerAuth) DeepCopyInto(out *ServerAuth) {
+	*out = *in
+	if in.APIKey != nil {
+		in, out := &in.APIKey, &out.APIKey
+		*out = new(APIKeyAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OAuth2ClientCredentials != nil {
+		in, out := &in.OAuth2ClientCredentials, &out.OAuth2ClientCredentials
+		*out = new(OAuth2ClientCredentialsAuth)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerAuth.
+func (in *ServerAuth) DeepCopy() *ServerAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServerConfig) DeepCopyInto(out *ServerConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerConfig.
@@ -585,6 +843,94 @@ func (in *ServerConfig) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerConfigGrant) DeepCopyInto(out *ServerConfigGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerConfigGrant.
+func (in *ServerConfigGrant) DeepCopy() *ServerConfigGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerConfigGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServerConfigGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerConfigGrantList) DeepCopyInto(out *ServerConfigGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ServerConfigGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerConfigGrantList.
+func (in *ServerConfigGrantList) DeepCopy() *ServerConfigGrantList {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerConfigGrantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServerConfigGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerConfigGrantSpec) DeepCopyInto(out *ServerConfigGrantSpec) {
+	*out = *in
+	if in.ServerConfigNames != nil {
+		in, out := &in.ServerConfigNames, &out.ServerConfigNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerConfigGrantSpec.
+func (in *ServerConfigGrantSpec) DeepCopy() *ServerConfigGrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerConfigGrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServerConfigList) DeepCopyInto(out *ServerConfigList) {
 	*out = *in
@@ -636,6 +982,21 @@ func (in *ServerConfigRef) DeepCopy() *ServerConfigRef {
 func (in *ServerConfigSpec) DeepCopyInto(out *ServerConfigSpec) {
 	*out = *in
 	in.APIKeySecretRef.DeepCopyInto(&out.APIKeySecretRef)
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(ServerAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ServerTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerConfigSpec.
@@ -651,6 +1012,17 @@ func (in *ServerConfigSpec) DeepCopy() *ServerConfigSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServerConfigStatus) DeepCopyInto(out *ServerConfigStatus) {
 	*out = *in
+	if in.LastValidated != nil {
+		in, out := &in.LastValidated, &out.LastValidated
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerConfigStatus.
@@ -663,6 +1035,105 @@ func (in *ServerConfigStatus) DeepCopy() *ServerConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerTLS) DeepCopyInto(out *ServerTLS) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = new(CABundleSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientCertSecretRef != nil {
+		in, out := &in.ClientCertSecretRef, &out.ClientCertSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerTLS.
+func (in *ServerTLS) DeepCopy() *ServerTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkPackageRelation) DeepCopyInto(out *WorkPackageRelation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkPackageRelation.
+func (in *WorkPackageRelation) DeepCopy() *WorkPackageRelation {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkPackageRelation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkPackageRelationResult) DeepCopyInto(out *WorkPackageRelationResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkPackageRelationResult.
+func (in *WorkPackageRelationResult) DeepCopy() *WorkPackageRelationResult {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkPackageRelationResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkPackageRun) DeepCopyInto(out *WorkPackageRun) {
+	*out = *in
+	in.ScheduledTime.DeepCopyInto(&out.ScheduledTime)
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkPackageRun.
+func (in *WorkPackageRun) DeepCopy() *WorkPackageRun {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkPackageRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkPackageRunRecord) DeepCopyInto(out *WorkPackageRunRecord) {
+	*out = *in
+	in.ScheduledTime.DeepCopyInto(&out.ScheduledTime)
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Relations != nil {
+		in, out := &in.Relations, &out.Relations
+		*out = make([]WorkPackageRelationResult, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkPackageRunRecord.
+func (in *WorkPackageRunRecord) DeepCopy() *WorkPackageRunRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkPackageRunRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkPackages) DeepCopyInto(out *WorkPackages) {
 	*out = *in
@@ -725,13 +1196,50 @@ func (in *WorkPackagesList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkPackagesSpec) DeepCopyInto(out *WorkPackagesSpec) {
 	*out = *in
+	if in.Watchers != nil {
+		in, out := &in.Watchers, &out.Watchers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Relations != nil {
+		in, out := &in.Relations, &out.Relations
+		*out = make([]WorkPackageRelation, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartingDeadlineSeconds != nil {
+		in, out := &in.StartingDeadlineSeconds, &out.StartingDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
 	out.ServerConfigRef = in.ServerConfigRef
 	in.AdditionalFields.DeepCopyInto(&out.AdditionalFields)
+	if in.CustomFields != nil {
+		in, out := &in.CustomFields, &out.CustomFields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.InventoryRef != nil {
 		in, out := &in.InventoryRef, &out.InventoryRef
 		*out = new(v1.LocalObjectReference)
 		**out = **in
 	}
+	if in.InventoryAttachment != nil {
+		in, out := &in.InventoryAttachment, &out.InventoryAttachment
+		*out = new(InventoryAttachmentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Findings != nil {
+		in, out := &in.Findings, &out.Findings
+		*out = new(FindingsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkPackagesSpec.
@@ -755,6 +1263,34 @@ func (in *WorkPackagesStatus) DeepCopyInto(out *WorkPackagesStatus) {
 		in, out := &in.NextRunTime, &out.NextRunTime
 		*out = (*in).DeepCopy()
 	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ActiveRun != nil {
+		in, out := &in.ActiveRun, &out.ActiveRun
+		*out = new(WorkPackageRun)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(DryRunResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]WorkPackageRunRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkPackagesStatus.
@@ -2,44 +2,285 @@ package configloader
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	openprojectv1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// LoadServerConfig fetches the singleton config in a namespace
-func LoadServerConfig(ctx context.Context, c client.Client, name string, namespace string) (*openprojectv1alpha1.ServerConfig, error) {
-	if name == "" {
+// ErrNotGranted is returned when a namespace may not use the ServerConfig it refers to
+var ErrNotGranted = errors.New("access not granted")
+
+// LoadServerConfig fetches the ServerConfig or ClusterServerConfig a WorkPackages in namespace
+// refers to, checking that the namespace may use it. A ClusterServerConfig is returned as a
+// ServerConfig in its credentials namespace.
+func LoadServerConfig(ctx context.Context, c client.Client, ref openprojectv1alpha1.ServerConfigRef, namespace string) (*openprojectv1alpha1.ServerConfig, error) {
+	if ref.Name == "" {
 		return nil, fmt.Errorf("serverConfigRef.Name is empty")
 	}
 
+	if ref.Kind == openprojectv1alpha1.ClusterServerConfigKind {
+		var cluster openprojectv1alpha1.ClusterServerConfig
+		if err := c.Get(ctx, client.ObjectKey{Name: ref.Name}, &cluster); err != nil {
+			return nil, fmt.Errorf("failed to get ClusterServerConfig: %w", err)
+		}
+		if cluster.Spec.NamespaceSelector == nil {
+			return nil, fmt.Errorf("ClusterServerConfig %q has no namespaceSelector: %w", ref.Name, ErrNotGranted)
+		}
+		matches, err := namespaceMatches(ctx, c, namespace, cluster.Spec.NamespaceSelector)
+		if err != nil {
+			return nil, err
+		}
+		if !matches {
+			return nil, fmt.Errorf("ClusterServerConfig %q does not select namespace %q: %w", ref.Name, namespace, ErrNotGranted)
+		}
+		return ServerConfigView(&cluster), nil
+	}
+
+	target := namespace
+	if ref.Namespace != "" {
+		target = ref.Namespace
+	}
 	var config openprojectv1alpha1.ServerConfig
-	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &config); err != nil {
+	if err := c.Get(ctx, client.ObjectKey{Namespace: target, Name: ref.Name}, &config); err != nil {
 		return nil, fmt.Errorf("failed to get ServerConfig: %w", err)
 	}
+	if target == namespace {
+		return &config, nil
+	}
+
+	granted, err := isGranted(ctx, c, &config, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if !granted {
+		return nil, fmt.Errorf("no ServerConfigGrant in namespace %q allows namespace %q to use ServerConfig %q: %w",
+			target, namespace, ref.Name, ErrNotGranted)
+	}
 	return &config, nil
 }
 
-func LoadAPIKey(ctx context.Context, c client.Client, config *openprojectv1alpha1.ServerConfig) (string, error) {
-	secretRef := config.Spec.APIKeySecretRef
-	var secret corev1.Secret
+// ServerConfigView returns a ClusterServerConfig as a ServerConfig in its credentials namespace,
+// so that credentials and TLS material are read from there. The UID is kept to tell the two apart.
+func ServerConfigView(cluster *openprojectv1alpha1.ClusterServerConfig) *openprojectv1alpha1.ServerConfig {
+	return &openprojectv1alpha1.ServerConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       cluster.Name,
+			Namespace:  cluster.Spec.CredentialsNamespace,
+			UID:        cluster.UID,
+			Generation: cluster.Generation,
+		},
+		Spec:   *cluster.Spec.ServerConfigSpec.DeepCopy(),
+		Status: *cluster.Status.DeepCopy(),
+	}
+}
+
+// isGranted reports whether a ServerConfigGrant in the config's namespace lets namespace use it
+func isGranted(ctx context.Context, c client.Client, config *openprojectv1alpha1.ServerConfig, namespace string) (bool, error) {
+	var grants openprojectv1alpha1.ServerConfigGrantList
+	if err := c.List(ctx, &grants, client.InNamespace(config.Namespace)); err != nil {
+		return false, fmt.Errorf("failed to list ServerConfigGrants: %w", err)
+	}
 
+	for _, grant := range grants.Items {
+		if len(grant.Spec.ServerConfigNames) > 0 && !slices.Contains(grant.Spec.ServerConfigNames, config.Name) {
+			continue
+		}
+		if slices.Contains(grant.Spec.Namespaces, namespace) {
+			return true, nil
+		}
+		if grant.Spec.NamespaceSelector == nil {
+			continue
+		}
+		matches, err := namespaceMatches(ctx, c, namespace, grant.Spec.NamespaceSelector)
+		if err != nil {
+			return false, err
+		}
+		if matches {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// namespaceMatches reports whether the labels of a namespace match a selector
+func namespaceMatches(ctx context.Context, c client.Client, namespace string, selector *metav1.LabelSelector) (bool, error) {
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false, fmt.Errorf("invalid namespaceSelector: %w", err)
+	}
+	var ns corev1.Namespace
+	if err := c.Get(ctx, client.ObjectKey{Name: namespace}, &ns); err != nil {
+		return false, fmt.Errorf("failed to get namespace %q: %w", namespace, err)
+	}
+	return sel.Matches(labels.Set(ns.Labels)), nil
+}
+
+// API key encodings
+const (
+	EncodingRaw    = "raw"
+	EncodingBase64 = "base64"
+)
+
+// readSecretKey reads one key of a Secret in the ServerConfig's namespace
+func readSecretKey(ctx context.Context, c client.Client, namespace string, ref corev1.SecretKeySelector) (string, error) {
+	var secret corev1.Secret
 	nsName := types.NamespacedName{
-		Name:      secretRef.Name,
-		Namespace: config.Namespace,
+		Name:      ref.Name,
+		Namespace: namespace,
 	}
 
 	if err := c.Get(ctx, nsName, &secret); err != nil {
-		return "", fmt.Errorf("failed to get API key secret: %w", err)
+		return "", fmt.Errorf("failed to get secret %q: %w", ref.Name, err)
 	}
 
-	tokenBytes, exists := secret.Data[secretRef.Key]
+	value, exists := secret.Data[ref.Key]
 	if !exists {
-		return "", fmt.Errorf("API key not found in secret under key %q", secretRef.Key)
+		return "", fmt.Errorf("key %q not found in secret %q", ref.Key, ref.Name)
+	}
+	return strings.TrimSpace(string(value)), nil
+}
+
+// LoadAPIKey returns the decoded API key of a ServerConfig. spec.auth.apiKey is decoded per its
+// encoding; the deprecated apiKeySecretRef keeps decoding values that look base64 encoded.
+func LoadAPIKey(ctx context.Context, c client.Client, config *openprojectv1alpha1.ServerConfig) (string, error) {
+	if config.Spec.Auth != nil && config.Spec.Auth.APIKey != nil {
+		auth := config.Spec.Auth.APIKey
+		key, err := readSecretKey(ctx, c, config.Namespace, auth.SecretRef)
+		if err != nil {
+			return "", fmt.Errorf("failed to load API key: %w", err)
+		}
+		if auth.Encoding != EncodingBase64 {
+			return key, nil
+		}
+		decoded, err := base64.StdEncoding.DecodeString(key)
+		if err != nil {
+			return "", fmt.Errorf("API key is not valid base64: %w", err)
+		}
+		return strings.TrimSpace(string(decoded)), nil
+	}
+
+	if config.Spec.APIKeySecretRef.Name == "" {
+		return "", fmt.Errorf("no API key configured")
+	}
+	key, err := readSecretKey(ctx, c, config.Namespace, config.Spec.APIKeySecretRef)
+	if err != nil {
+		return "", fmt.Errorf("failed to load API key: %w", err)
+	}
+	if decoded, err := base64.StdEncoding.DecodeString(key); err == nil {
+		key = strings.TrimSpace(string(decoded))
+	}
+	return key, nil
+}
+
+// LoadOAuth2Credentials returns the client ID and secret of a ServerConfig using the OAuth2
+// client credentials flow
+func LoadOAuth2Credentials(ctx context.Context, c client.Client, config *openprojectv1alpha1.ServerConfig) (string, string, error) {
+	if config.Spec.Auth == nil || config.Spec.Auth.OAuth2ClientCredentials == nil {
+		return "", "", fmt.Errorf("no OAuth2 client credentials configured")
+	}
+	auth := config.Spec.Auth.OAuth2ClientCredentials
+
+	clientID, err := readSecretKey(ctx, c, config.Namespace, auth.ClientIDSecretRef)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to load OAuth2 client ID: %w", err)
+	}
+	clientSecret, err := readSecretKey(ctx, c, config.Namespace, auth.ClientSecretSecretRef)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to load OAuth2 client secret: %w", err)
 	}
+	return clientID, clientSecret, nil
+}
+
+// TLSMaterial is the PEM encoded TLS configuration of a ServerConfig
+type TLSMaterial struct {
+	CABundle []byte
+	CertPEM  []byte
+	KeyPEM   []byte
+}
 
-	return string(tokenBytes), nil
+// LoadTLSMaterial reads the CA bundle and client certificate of a ServerConfig; nil without spec.tls
+func LoadTLSMaterial(ctx context.Context, c client.Client, config *openprojectv1alpha1.ServerConfig) (*TLSMaterial, error) {
+	spec := config.Spec.TLS
+	if spec == nil {
+		return nil, nil
+	}
+
+	material := &TLSMaterial{}
+	if bundle := spec.CABundle; bundle != nil {
+		switch {
+		case bundle.ConfigMapRef != nil:
+			var cm corev1.ConfigMap
+			nsName := types.NamespacedName{Name: bundle.ConfigMapRef.Name, Namespace: config.Namespace}
+			if err := c.Get(ctx, nsName, &cm); err != nil {
+				return nil, fmt.Errorf("failed to get CA bundle configmap %q: %w", nsName.Name, err)
+			}
+			data, exists := cm.Data[bundle.ConfigMapRef.Key]
+			if !exists {
+				return nil, fmt.Errorf("key %q not found in configmap %q", bundle.ConfigMapRef.Key, nsName.Name)
+			}
+			material.CABundle = []byte(data)
+		case bundle.SecretRef != nil:
+			data, err := readSecretKey(ctx, c, config.Namespace, *bundle.SecretRef)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load CA bundle: %w", err)
+			}
+			material.CABundle = []byte(data)
+		}
+	}
+
+	if ref := spec.ClientCertSecretRef; ref != nil {
+		cert, err := readSecretKey(ctx, c, config.Namespace, corev1.SecretKeySelector{LocalObjectReference: *ref, Key: corev1.TLSCertKey})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		key, err := readSecretKey(ctx, c, config.Namespace, corev1.SecretKeySelector{LocalObjectReference: *ref, Key: corev1.TLSPrivateKeyKey})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client key: %w", err)
+		}
+		material.CertPEM, material.KeyPEM = []byte(cert), []byte(key)
+	}
+	return material, nil
+}
+
+// ConfigMapNames returns the ConfigMaps a ServerConfig reads
+func ConfigMapNames(config *openprojectv1alpha1.ServerConfig) []string {
+	if config.Spec.TLS != nil && config.Spec.TLS.CABundle != nil && config.Spec.TLS.CABundle.ConfigMapRef != nil {
+		return []string{config.Spec.TLS.CABundle.ConfigMapRef.Name}
+	}
+	return nil
+}
+
+// SecretNames returns the Secrets a ServerConfig reads its credentials and TLS material from
+func SecretNames(config *openprojectv1alpha1.ServerConfig) []string {
+	var names []string
+	if tls := config.Spec.TLS; tls != nil {
+		if tls.CABundle != nil && tls.CABundle.SecretRef != nil {
+			names = append(names, tls.CABundle.SecretRef.Name)
+		}
+		if tls.ClientCertSecretRef != nil {
+			names = append(names, tls.ClientCertSecretRef.Name)
+		}
+	}
+	if auth := config.Spec.Auth; auth != nil {
+		if auth.APIKey != nil {
+			names = append(names, auth.APIKey.SecretRef.Name)
+		}
+		if auth.OAuth2ClientCredentials != nil {
+			names = append(names, auth.OAuth2ClientCredentials.ClientIDSecretRef.Name,
+				auth.OAuth2ClientCredentials.ClientSecretSecretRef.Name)
+		}
+	}
+	if config.Spec.APIKeySecretRef.Name != "" {
+		names = append(names, config.Spec.APIKeySecretRef.Name)
+	}
+	return names
 }
@@ -0,0 +1,283 @@
+package configloader
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	openprojectv1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newFakeClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := openprojectv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func apiKeySecret(value string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "openproject", Namespace: "team-a"},
+		Data:       map[string][]byte{"apiKey": []byte(value)},
+	}
+}
+
+func TestLoadAPIKey(t *testing.T) {
+	ref := corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "openproject"},
+		Key:                  "apiKey",
+	}
+	missingRef := corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "openproject"},
+		Key:                  "token",
+	}
+
+	tests := []struct {
+		name    string
+		spec    openprojectv1alpha1.ServerConfigSpec
+		secret  *corev1.Secret
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "raw encoding keeps the value",
+			spec:   openprojectv1alpha1.ServerConfigSpec{Auth: &openprojectv1alpha1.ServerAuth{APIKey: &openprojectv1alpha1.APIKeyAuth{SecretRef: ref}}},
+			secret: apiKeySecret("c2VjcmV0\n"),
+			want:   "c2VjcmV0",
+		},
+		{
+			name: "base64 encoding decodes the value",
+			spec: openprojectv1alpha1.ServerConfigSpec{Auth: &openprojectv1alpha1.ServerAuth{APIKey: &openprojectv1alpha1.APIKeyAuth{
+				SecretRef: ref, Encoding: EncodingBase64,
+			}}},
+			secret: apiKeySecret("c2VjcmV0"),
+			want:   "secret",
+		},
+		{
+			name: "base64 encoding rejects invalid values",
+			spec: openprojectv1alpha1.ServerConfigSpec{Auth: &openprojectv1alpha1.ServerAuth{APIKey: &openprojectv1alpha1.APIKeyAuth{
+				SecretRef: ref, Encoding: EncodingBase64,
+			}}},
+			secret:  apiKeySecret("not base64!"),
+			wantErr: true,
+		},
+		{
+			name:   "deprecated ref decodes base64 values",
+			spec:   openprojectv1alpha1.ServerConfigSpec{APIKeySecretRef: ref},
+			secret: apiKeySecret("c2VjcmV0"),
+			want:   "secret",
+		},
+		{
+			name:   "deprecated ref keeps other values",
+			spec:   openprojectv1alpha1.ServerConfigSpec{APIKeySecretRef: ref},
+			secret: apiKeySecret("not base64!"),
+			want:   "not base64!",
+		},
+		{
+			name:    "missing key",
+			spec:    openprojectv1alpha1.ServerConfigSpec{APIKeySecretRef: missingRef},
+			secret:  apiKeySecret("secret"),
+			wantErr: true,
+		},
+		{
+			name:    "missing secret",
+			spec:    openprojectv1alpha1.ServerConfigSpec{APIKeySecretRef: ref},
+			wantErr: true,
+		},
+		{
+			name:    "no API key configured",
+			spec:    openprojectv1alpha1.ServerConfigSpec{},
+			secret:  apiKeySecret("secret"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var objs []client.Object
+			if tt.secret != nil {
+				objs = append(objs, tt.secret)
+			}
+			c := newFakeClient(t, objs...)
+			config := &openprojectv1alpha1.ServerConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "openproject", Namespace: "team-a"},
+				Spec:       tt.spec,
+			}
+
+			got, err := LoadAPIKey(context.Background(), c, config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadAPIKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("LoadAPIKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadServerConfig(t *testing.T) {
+	namespaces := []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shared"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"openproject": "enabled"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}},
+	}
+	shared := &openprojectv1alpha1.ServerConfig{ObjectMeta: metav1.ObjectMeta{Name: "openproject", Namespace: "shared"}}
+	enabled := &metav1.LabelSelector{MatchLabels: map[string]string{"openproject": "enabled"}}
+	grant := func(name string, spec openprojectv1alpha1.ServerConfigGrantSpec) *openprojectv1alpha1.ServerConfigGrant {
+		return &openprojectv1alpha1.ServerConfigGrant{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shared"}, Spec: spec}
+	}
+	cluster := func(selector *metav1.LabelSelector) *openprojectv1alpha1.ClusterServerConfig {
+		return &openprojectv1alpha1.ClusterServerConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "openproject"},
+			Spec: openprojectv1alpha1.ClusterServerConfigSpec{
+				ServerConfigSpec:     openprojectv1alpha1.ServerConfigSpec{Server: "https://openproject.example.com"},
+				CredentialsNamespace: "shared",
+				NamespaceSelector:    selector,
+			},
+		}
+	}
+	sharedRef := openprojectv1alpha1.ServerConfigRef{Name: "openproject", Namespace: "shared"}
+	clusterRef := openprojectv1alpha1.ServerConfigRef{Name: "openproject", Kind: openprojectv1alpha1.ClusterServerConfigKind}
+
+	tests := []struct {
+		name          string
+		objs          []client.Object
+		ref           openprojectv1alpha1.ServerConfigRef
+		namespace     string
+		wantNamespace string
+		wantErr       bool
+		wantDenied    bool
+	}{
+		{
+			name:          "same namespace needs no grant",
+			objs:          []client.Object{shared},
+			ref:           openprojectv1alpha1.ServerConfigRef{Name: "openproject"},
+			namespace:     "shared",
+			wantNamespace: "shared",
+		},
+		{
+			name:      "empty name",
+			ref:       openprojectv1alpha1.ServerConfigRef{},
+			namespace: "team-a",
+			wantErr:   true,
+		},
+		{
+			name:      "missing ServerConfig",
+			ref:       sharedRef,
+			namespace: "team-a",
+			wantErr:   true,
+		},
+		{
+			name:       "cross namespace without grant",
+			objs:       []client.Object{shared},
+			ref:        sharedRef,
+			namespace:  "team-a",
+			wantErr:    true,
+			wantDenied: true,
+		},
+		{
+			name:          "grant by namespace name",
+			objs:          []client.Object{shared, grant("team-a", openprojectv1alpha1.ServerConfigGrantSpec{Namespaces: []string{"team-a"}})},
+			ref:           sharedRef,
+			namespace:     "team-a",
+			wantNamespace: "shared",
+		},
+		{
+			name:          "grant by namespace selector",
+			objs:          []client.Object{shared, grant("enabled", openprojectv1alpha1.ServerConfigGrantSpec{NamespaceSelector: enabled})},
+			ref:           sharedRef,
+			namespace:     "team-a",
+			wantNamespace: "shared",
+		},
+		{
+			name:       "grant selector does not match",
+			objs:       []client.Object{shared, grant("enabled", openprojectv1alpha1.ServerConfigGrantSpec{NamespaceSelector: enabled})},
+			ref:        sharedRef,
+			namespace:  "team-b",
+			wantErr:    true,
+			wantDenied: true,
+		},
+		{
+			name: "grant for another ServerConfig",
+			objs: []client.Object{shared, grant("other", openprojectv1alpha1.ServerConfigGrantSpec{
+				ServerConfigNames: []string{"other"},
+				Namespaces:        []string{"team-a"},
+			})},
+			ref:        sharedRef,
+			namespace:  "team-a",
+			wantErr:    true,
+			wantDenied: true,
+		},
+		{
+			name: "grant for this ServerConfig",
+			objs: []client.Object{shared, grant("openproject", openprojectv1alpha1.ServerConfigGrantSpec{
+				ServerConfigNames: []string{"openproject"},
+				Namespaces:        []string{"team-a"},
+			})},
+			ref:           sharedRef,
+			namespace:     "team-a",
+			wantNamespace: "shared",
+		},
+		{
+			name:          "ClusterServerConfig selecting the namespace",
+			objs:          []client.Object{cluster(enabled)},
+			ref:           clusterRef,
+			namespace:     "team-a",
+			wantNamespace: "shared",
+		},
+		{
+			name:       "ClusterServerConfig not selecting the namespace",
+			objs:       []client.Object{cluster(enabled)},
+			ref:        clusterRef,
+			namespace:  "team-b",
+			wantErr:    true,
+			wantDenied: true,
+		},
+		{
+			name:       "ClusterServerConfig without namespaceSelector",
+			objs:       []client.Object{cluster(nil)},
+			ref:        clusterRef,
+			namespace:  "team-a",
+			wantErr:    true,
+			wantDenied: true,
+		},
+		{
+			name:          "ClusterServerConfig selecting every namespace",
+			objs:          []client.Object{cluster(&metav1.LabelSelector{})},
+			ref:           clusterRef,
+			namespace:     "team-b",
+			wantNamespace: "shared",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newFakeClient(t, append(tt.objs, namespaces...)...)
+
+			got, err := LoadServerConfig(context.Background(), c, tt.ref, tt.namespace)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadServerConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if errors.Is(err, ErrNotGranted) != tt.wantDenied {
+				t.Fatalf("LoadServerConfig() error = %v, want ErrNotGranted %v", err, tt.wantDenied)
+			}
+			if err != nil {
+				return
+			}
+			if got.Namespace != tt.wantNamespace {
+				t.Errorf("LoadServerConfig() namespace = %q, want %q", got.Namespace, tt.wantNamespace)
+			}
+		})
+	}
+}
@@ -36,6 +36,7 @@ func (r *CloudInventoryReconciler) reconcileAWS(ctx context.Context, ci *v1alpha
 	if ci.Spec.AWS == nil {
 		err := fmt.Errorf("AWS config is missing in spec")
 		log.Error(err, "cannot reconcile AWS")
+		r.markInventoryFailed(ctx, ci, original, err)
 		return ctrl.Result{}, err
 	}
 
@@ -46,9 +47,8 @@ func (r *CloudInventoryReconciler) reconcileAWS(ctx context.Context, ci *v1alpha
 	awsConfig, accountID, err := r.buildAWSConfig(ctx, ci, log)
 	if err != nil {
 		log.Error(err, "Failed to build AWS config")
-		ci.Status.LastFailedTime = &metav1.Time{Time: time.Now()}
-		_ = r.Status().Patch(ctx, ci, client.MergeFrom(original))
-		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+		r.markInventoryFailed(ctx, ci, original, err)
+		return ctrl.Result{}, err
 	}
 
 	// summary counts and raw inventories
@@ -64,9 +64,8 @@ func (r *CloudInventoryReconciler) reconcileAWS(ctx context.Context, ci *v1alpha
 				items, err := info.Inventory(ctx, *awsConfig, ci.Spec.AWS.TagFilter)
 				if err != nil {
 					log.Error(err, fmt.Sprintf("%s inventory failed", info.Name))
-					ci.Status.LastFailedTime = &metav1.Time{Time: time.Now()}
-					_ = r.Status().Patch(ctx, ci, client.MergeFrom(original))
-					return ctrl.Result{RequeueAfter: 30 * time.Minute}, nil
+					r.markInventoryFailed(ctx, ci, original, err)
+					return ctrl.Result{}, err
 				}
 				summary[lower] = info.Count(items)
 				rawResults[lower] = items
@@ -104,6 +103,7 @@ func (r *CloudInventoryReconciler) reconcileAWS(ctx context.Context, ci *v1alpha
 				log.Info("🛑 Skipping report creation — last report is recent", "age", time.Since(latestReport.CreationTimestamp.Time))
 				ci.Status.LastRunTime = metav1.Now()
 				ci.Status.Message = "Recent inventory already reported"
+				markInventorySucceeded(ci, ci.Status.Message)
 				_ = r.Status().Patch(ctx, ci, client.MergeFrom(original))
 				return ctrl.Result{}, nil
 			}
@@ -130,9 +130,8 @@ func (r *CloudInventoryReconciler) reconcileAWS(ctx context.Context, ci *v1alpha
 	// Create the report resource first
 	if err := r.Create(ctx, report); err != nil {
 		log.Error(err, "Failed to create CloudInventoryReport")
-		ci.Status.LastFailedTime = &metav1.Time{Time: time.Now()}
-		_ = r.Status().Patch(ctx, ci, client.MergeFrom(original))
-		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+		r.markInventoryFailed(ctx, ci, original, err)
+		return ctrl.Result{}, err
 	}
 
 	log.Info("Created empty CloudInventoryReport", "name", report.Name)
@@ -166,14 +165,15 @@ func (r *CloudInventoryReconciler) reconcileAWS(ctx context.Context, ci *v1alpha
 		}
 	}
 
+	markReportPopulated(report)
 	if err := r.Status().Patch(ctx, report, client.MergeFrom(reportCopy)); err != nil {
 		log.Error(err, "failed to patch CloudInventoryReport status", "name", report.Name)
-		ci.Status.LastFailedTime = &metav1.Time{Time: time.Now()}
-		_ = r.Status().Patch(ctx, ci, client.MergeFrom(original))
-		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+		r.markInventoryFailed(ctx, ci, original, err)
+		return ctrl.Result{}, err
 	}
 
 	log.Info("✅ CloudInventoryReport created and status patched", "name", report.Name)
+	r.Recorder.Eventf(ci, corev1.EventTypeNormal, v1alpha1.ReasonReportCreated, "Created CloudInventoryReport %s", report.Name)
 
 	ci.Status.LastFailedTime = nil
 	ci.Status.LastRunTime = metav1.Now()
@@ -185,6 +185,8 @@ func (r *CloudInventoryReconciler) reconcileAWS(ctx context.Context, ci *v1alpha
 	ci.Status.Summary = summary
 	ci.Status.Message = fmt.Sprintf("AWS Inventory complete for account %s", accountID)
 
+	markInventorySucceeded(ci, ci.Status.Message)
+
 	log.Info("AWS Inventory Summary", "summary", ci.Status.Summary)
 
 	if err := r.Status().Patch(ctx, ci, client.MergeFrom(original)); err != nil {
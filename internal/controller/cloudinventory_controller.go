@@ -2,28 +2,40 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
 )
 
+// errNoInventorySpec is returned when a CloudInventory has neither an AWS nor a Kubernetes spec
+var errNoInventorySpec = errors.New("no AWS or Kubernetes inventory spec defined")
+
 // CloudInventoryReconciler reconciles a CloudInventory object
 type CloudInventoryReconciler struct {
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=openproject.org,resources=cloudinventories,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=openproject.org,resources=cloudinventories/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=openproject.org,resources=cloudinventoryreports,verbs=get;list;watch;create
-// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
-// Reconcile entry point only run when called by WorkPackage
+// Reconcile runs scheduled inventories; unscheduled ones are only run when called by WorkPackage
 func (r *CloudInventoryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("CloudInventory", req.NamespacedName)
 
@@ -33,12 +45,159 @@ func (r *CloudInventoryReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	return ctrl.Result{}, nil
+	if ci.Spec.Schedule == "" {
+		return ctrl.Result{}, nil
+	}
+
+	now := time.Now()
+
+	// First reconcile of a scheduled inventory, or one whose spec was edited, only records the next run
+	if ci.Status.NextRunTime == nil || ci.Generation != ci.Status.ObservedGeneration {
+		next, err := nextInventoryRun(&ci, now)
+		if err != nil {
+			log.Error(err, "❌ Failed to parse cron schedule", "schedule", ci.Spec.Schedule)
+			return ctrl.Result{}, r.patchInventorySchedule(ctx, &ci, nil, "Invalid schedule: "+err.Error())
+		}
+		if err := r.patchInventorySchedule(ctx, &ci, &metav1.Time{Time: next}, "Next inventory run scheduled"); err != nil {
+			log.Error(err, "❌ Failed to patch inventory schedule")
+			return ctrl.Result{}, err
+		}
+		log.Info("✅ Inventory scheduled", "nextRunTime", next.Format(time.RFC3339))
+		return ctrl.Result{RequeueAfter: time.Until(next)}, nil
+	}
+
+	if now.Before(ci.Status.NextRunTime.Time) {
+		return ctrl.Result{RequeueAfter: time.Until(ci.Status.NextRunTime.Time)}, nil
+	}
+
+	log.Info("🔄 Running scheduled inventory", "mode", ci.Spec.Mode)
+	message := ""
+	if _, err := r.runInventory(ctx, &ci, log); err != nil {
+		log.Error(err, "❌ Scheduled inventory failed")
+		message = "Scheduled inventory failed: " + err.Error()
+		// The collectors record their own failures; an inventory without a spec has none to run
+		if errors.Is(err, errNoInventorySpec) {
+			r.markInventoryFailed(ctx, &ci, ci.DeepCopy(), err)
+		}
+	}
+
+	// A failed run waits for the next period, the same way WorkPackages do
+	next, err := nextInventoryRun(&ci, time.Now())
+	if err != nil {
+		log.Error(err, "❌ Failed to parse cron schedule", "schedule", ci.Spec.Schedule)
+		return ctrl.Result{}, r.patchInventorySchedule(ctx, &ci, nil, "Invalid schedule: "+err.Error())
+	}
+	if err := r.patchInventorySchedule(ctx, &ci, &metav1.Time{Time: next}, message); err != nil {
+		log.Error(err, "❌ Failed to patch inventory schedule")
+		return ctrl.Result{}, err
+	}
+
+	log.Info("⏳ Next inventory run scheduled", "nextRunTime", next.Format(time.RFC3339))
+	return ctrl.Result{RequeueAfter: time.Until(next)}, nil
+}
+
+// nextInventoryRun returns the next run of a CloudInventory schedule after from, in its time zone
+func nextInventoryRun(ci *v1alpha1.CloudInventory, from time.Time) (time.Time, error) {
+	loc, err := loadTimeZone(ci.Spec.TimeZone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return calculateNextRunTime(ci.Spec.Schedule, from, loc)
+}
+
+// runInventory dispatches a scan to the collector matching the inventory mode
+func (r *CloudInventoryReconciler) runInventory(ctx context.Context, ci *v1alpha1.CloudInventory, log logr.Logger) (ctrl.Result, error) {
+	switch ci.Spec.Mode {
+	case "aws":
+		return r.reconcileAWS(ctx, ci, log)
+	case "kubernetes":
+		return r.reconcileKubernetes(ctx, ci, log)
+	}
+
+	// Fall back to whichever spec is present
+	if ci.Spec.AWS != nil {
+		return r.reconcileAWS(ctx, ci, log)
+	}
+	if ci.Spec.Kubernetes != nil {
+		return r.reconcileKubernetes(ctx, ci, log)
+	}
+	return ctrl.Result{}, errNoInventorySpec
+}
+
+// patchInventorySchedule records the next scheduled run and an optional message
+func (r *CloudInventoryReconciler) patchInventorySchedule(ctx context.Context, ci *v1alpha1.CloudInventory, next *metav1.Time, message string) error {
+	original := ci.DeepCopy()
+	if loc, err := loadTimeZone(ci.Spec.TimeZone); err == nil {
+		ci.Status.TimeZone = timeZoneName(loc)
+	}
+	if next != nil {
+		ci.Status.NextRunTime = next
+	} else {
+		// Only an invalid schedule leaves the next run unset
+		setConditions(&ci.Status.Conditions, ci.Generation,
+			newCondition(v1alpha1.ConditionReady, metav1.ConditionFalse, v1alpha1.ReasonInvalidSpec, message))
+		r.Recorder.Event(ci, corev1.EventTypeWarning, v1alpha1.ReasonInvalidSpec, message)
+	}
+	ci.Status.ObservedGeneration = ci.Generation
+	if message != "" {
+		ci.Status.Message = message
+	}
+	return r.Status().Patch(ctx, ci, client.MergeFrom(original))
+}
+
+// markInventoryFailed records a failed inventory run
+func (r *CloudInventoryReconciler) markInventoryFailed(ctx context.Context, ci, original *v1alpha1.CloudInventory, cause error) {
+	ci.Status.LastFailedTime = &metav1.Time{Time: time.Now()}
+	ci.Status.LastRunSuccess = false
+	ci.Status.Message = "Inventory failed: " + cause.Error()
+	ci.Status.ObservedGeneration = ci.Generation
+	setConditions(&ci.Status.Conditions, ci.Generation,
+		newCondition(v1alpha1.ConditionLastRunSucceeded, metav1.ConditionFalse, v1alpha1.ReasonInventoryFailed, cause.Error()),
+		newCondition(v1alpha1.ConditionDegraded, metav1.ConditionTrue, v1alpha1.ReasonInventoryFailed, cause.Error()),
+		newCondition(v1alpha1.ConditionReady, metav1.ConditionFalse, v1alpha1.ReasonInventoryFailed, cause.Error()))
+	_ = r.Status().Patch(ctx, ci, client.MergeFrom(original))
+	r.Recorder.Event(ci, corev1.EventTypeWarning, v1alpha1.ReasonInventoryFailed, ci.Status.Message)
+}
+
+// markInventorySucceeded sets the conditions of a completed inventory run
+func markInventorySucceeded(ci *v1alpha1.CloudInventory, message string) {
+	ci.Status.ObservedGeneration = ci.Generation
+	setConditions(&ci.Status.Conditions, ci.Generation,
+		newCondition(v1alpha1.ConditionInventoryReady, metav1.ConditionTrue, v1alpha1.ReasonInventoryCompleted, message),
+		newCondition(v1alpha1.ConditionLastRunSucceeded, metav1.ConditionTrue, v1alpha1.ReasonInventoryCompleted, message),
+		newCondition(v1alpha1.ConditionDegraded, metav1.ConditionFalse, v1alpha1.ReasonAsExpected, ""),
+		newCondition(v1alpha1.ConditionReady, metav1.ConditionTrue, v1alpha1.ReasonInventoryCompleted, message))
+}
+
+// markReportPopulated sets the conditions of a report whose status has been filled in
+func markReportPopulated(report *v1alpha1.CloudInventoryReport) {
+	report.Status.ObservedGeneration = report.Generation
+	setConditions(&report.Status.Conditions, report.Generation,
+		newCondition(v1alpha1.ConditionInventoryReady, metav1.ConditionTrue, v1alpha1.ReasonReportPopulated, "Inventory results recorded"),
+		newCondition(v1alpha1.ConditionReady, metav1.ConditionTrue, v1alpha1.ReasonReportPopulated, "Inventory results recorded"))
 }
 
 // SetupWithManager wires up the controller to the manager
 func (r *CloudInventoryReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.CloudInventory{}).
+		WatchesMetadata(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.inventoriesReading)).
 		Complete(r)
 }
+
+// inventoriesReading maps a Secret to the CloudInventories in its namespace using it as AWS
+// credentials or kubeconfig
+func (r *CloudInventoryReconciler) inventoriesReading(ctx context.Context, obj client.Object) []reconcile.Request {
+	var inventories v1alpha1.CloudInventoryList
+	if err := r.List(ctx, &inventories, client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{IndexFieldSecretNames: obj.GetName()}); err != nil {
+		r.Log.Error(err, "❌ Unable to list CloudInventories", "secret", obj.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(inventories.Items))
+	for i := range inventories.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&inventories.Items[i])})
+	}
+	return requests
+}
@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCloudInventoryReconcileSchedule(t *testing.T) {
+	due := metav1.NewTime(time.Now().Add(-time.Minute))
+
+	tests := []struct {
+		name         string
+		spec         v1alpha1.CloudInventorySpec
+		status       v1alpha1.CloudInventoryStatus
+		wantTimeZone string
+		wantReady    metav1.ConditionStatus
+		wantFailed   bool
+		wantMessage  string
+	}{
+		{
+			name:         "schedule evaluated in the time zone",
+			spec:         v1alpha1.CloudInventorySpec{Mode: "aws", Schedule: "0 9 * * *", TimeZone: "Europe/Berlin"},
+			wantTimeZone: "Europe/Berlin",
+		},
+		{
+			name:        "unknown time zone",
+			spec:        v1alpha1.CloudInventorySpec{Mode: "aws", Schedule: "0 9 * * *", TimeZone: "Mars/Olympus_Mons"},
+			wantReady:   metav1.ConditionFalse,
+			wantMessage: "Invalid schedule: invalid timeZone",
+		},
+		{
+			name:         "failed AWS run is surfaced",
+			spec:         v1alpha1.CloudInventorySpec{Mode: "aws", Schedule: "0 9 * * *", TimeZone: "Europe/Berlin"},
+			status:       v1alpha1.CloudInventoryStatus{NextRunTime: &due},
+			wantTimeZone: "Europe/Berlin",
+			wantReady:    metav1.ConditionFalse,
+			wantFailed:   true,
+			wantMessage:  "Scheduled inventory failed: AWS config is missing in spec",
+		},
+		{
+			name:         "run without an inventory spec is surfaced",
+			spec:         v1alpha1.CloudInventorySpec{Schedule: "0 9 * * *", TimeZone: "UTC"},
+			status:       v1alpha1.CloudInventoryStatus{NextRunTime: &due},
+			wantTimeZone: "UTC",
+			wantReady:    metav1.ConditionFalse,
+			wantFailed:   true,
+			wantMessage:  "Scheduled inventory failed: " + errNoInventorySpec.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ci := &v1alpha1.CloudInventory{
+				ObjectMeta: metav1.ObjectMeta{Name: "inventory", Namespace: "default"},
+				Spec:       tt.spec,
+				Status:     tt.status,
+			}
+			scheme := newTestScheme(t)
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ci).WithStatusSubresource(ci).Build()
+			r := &CloudInventoryReconciler{Client: c, Log: logr.Discard(), Scheme: scheme, Recorder: record.NewFakeRecorder(10)}
+
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(ci)}); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			var got v1alpha1.CloudInventory
+			if err := c.Get(context.Background(), client.ObjectKeyFromObject(ci), &got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Status.TimeZone != tt.wantTimeZone {
+				t.Errorf("status.timeZone = %q, want %q", got.Status.TimeZone, tt.wantTimeZone)
+			}
+			// Every case runs at 09:00 in its zone; an invalid zone leaves the next run unset
+			if tt.wantTimeZone == "" {
+				if got.Status.NextRunTime != nil {
+					t.Errorf("status.nextRunTime = %v, want none", got.Status.NextRunTime)
+				}
+			} else {
+				loc, err := time.LoadLocation(tt.wantTimeZone)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if next := got.Status.NextRunTime; next == nil || next.In(loc).Hour() != 9 {
+					t.Errorf("status.nextRunTime = %v, want 09:00 in %s", next, tt.wantTimeZone)
+				}
+			}
+			if tt.wantReady != "" && !meta.IsStatusConditionPresentAndEqual(got.Status.Conditions, v1alpha1.ConditionReady, tt.wantReady) {
+				t.Errorf("conditions = %v, want Ready %s", got.Status.Conditions, tt.wantReady)
+			}
+			if failed := meta.IsStatusConditionFalse(got.Status.Conditions, v1alpha1.ConditionLastRunSucceeded); failed != tt.wantFailed {
+				t.Errorf("LastRunSucceeded false = %v, want %v", failed, tt.wantFailed)
+			}
+			if !strings.HasPrefix(got.Status.Message, tt.wantMessage) {
+				t.Errorf("status.message = %q, want prefix %q", got.Status.Message, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -38,8 +38,7 @@ func (r *CloudInventoryReconciler) reconcileKubernetes(ctx context.Context, ci *
 		// Remote cluster
 		restConfig, clusterName, err = r.buildRemoteKubeConfig(ctx, ci, log)
 		if err != nil {
-			ci.Status.LastFailedTime = &metav1.Time{Time: time.Now()}
-			_ = r.Status().Patch(ctx, ci, client.MergeFrom(original))
+			r.markInventoryFailed(ctx, ci, original, err)
 			return ctrl.Result{}, err
 		}
 	} else {
@@ -47,8 +46,7 @@ func (r *CloudInventoryReconciler) reconcileKubernetes(ctx context.Context, ci *
 		restConfig, err = ctrl.GetConfig()
 		clusterName = "operator-local"
 		if err != nil {
-			ci.Status.LastFailedTime = &metav1.Time{Time: time.Now()}
-			_ = r.Status().Patch(ctx, ci, client.MergeFrom(original))
+			r.markInventoryFailed(ctx, ci, original, err)
 			return ctrl.Result{}, err
 		}
 	}
@@ -57,8 +55,7 @@ func (r *CloudInventoryReconciler) reconcileKubernetes(ctx context.Context, ci *
 	clientset, err = kubernetes.NewForConfig(restConfig)
 	if err != nil {
 		log.Error(err, "failed to create Kubernetes client")
-		ci.Status.LastFailedTime = &metav1.Time{Time: time.Now()}
-		_ = r.Status().Patch(ctx, ci, client.MergeFrom(original))
+		r.markInventoryFailed(ctx, ci, original, err)
 		return ctrl.Result{}, err
 	}
 
@@ -71,8 +68,7 @@ func (r *CloudInventoryReconciler) reconcileKubernetes(ctx context.Context, ci *
 	podList, err := clientset.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		log.Error(err, "failed to list pods")
-		ci.Status.LastFailedTime = &metav1.Time{Time: time.Now()}
-		_ = r.Status().Patch(ctx, ci, client.MergeFrom(original))
+		r.markInventoryFailed(ctx, ci, original, err)
 		return ctrl.Result{}, err
 	}
 
@@ -120,6 +116,7 @@ func (r *CloudInventoryReconciler) reconcileKubernetes(ctx context.Context, ci *
 			log.Info("🛑 Skipping report creation — last report is recent", "age", time.Since(latest.CreationTimestamp.Time))
 			ci.Status.LastRunTime = metav1.Now()
 			ci.Status.Message = "Recent inventory already reported"
+			markInventorySucceeded(ci, ci.Status.Message)
 			_ = r.Status().Patch(ctx, ci, client.MergeFrom(original))
 			return ctrl.Result{}, nil
 		}
@@ -143,8 +140,7 @@ func (r *CloudInventoryReconciler) reconcileKubernetes(ctx context.Context, ci *
 	}
 	if err := r.Create(ctx, report); err != nil {
 		log.Error(err, "failed to create CloudInventoryReport")
-		ci.Status.LastFailedTime = &metav1.Time{Time: time.Now()}
-		_ = r.Status().Patch(ctx, ci, client.MergeFrom(original))
+		r.markInventoryFailed(ctx, ci, original, err)
 		return ctrl.Result{RequeueAfter: 10 * time.Minute}, nil
 	}
 	log.Info("Created empty CloudInventoryReport", "name", report.Name)
@@ -155,13 +151,14 @@ func (r *CloudInventoryReconciler) reconcileKubernetes(ctx context.Context, ci *
 		ContainerImages: images,
 		Summary:         summary,
 	}
+	markReportPopulated(report)
 	if err := r.Status().Patch(ctx, report, client.MergeFrom(reportCopy)); err != nil {
 		log.Error(err, "failed to patch CloudInventoryReport status", "name", report.Name)
-		ci.Status.LastFailedTime = &metav1.Time{Time: time.Now()}
-		_ = r.Status().Patch(ctx, ci, client.MergeFrom(original))
+		r.markInventoryFailed(ctx, ci, original, err)
 		return ctrl.Result{RequeueAfter: 10 * time.Minute}, nil
 	}
 	log.Info("✅ CloudInventoryReport created and status patched", "name", report.Name)
+	r.Recorder.Eventf(ci, corev1.EventTypeNormal, v1alpha1.ReasonReportCreated, "Created CloudInventoryReport %s", report.Name)
 
 	// Finally, patch our CloudInventory CR’s status
 	ci.Status.LastFailedTime = nil
@@ -170,6 +167,7 @@ func (r *CloudInventoryReconciler) reconcileKubernetes(ctx context.Context, ci *
 	ci.Status.ItemCount = summary["pods"]
 	ci.Status.Summary = summary
 	ci.Status.Message = fmt.Sprintf("Kubernetes inventory complete for cluster %s", clusterName)
+	markInventorySucceeded(ci, ci.Status.Message)
 
 	if err := r.Status().Patch(ctx, ci, client.MergeFrom(original)); err != nil {
 		log.Error(err, "Failed to patch Kubernetes inventory status")
@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	openprojectv1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	"github.com/shrapk2/openproject-operator/internal/configloader"
+)
+
+type ClusterServerConfigReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=openproject.org,resources=clusterserverconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=openproject.org,resources=clusterserverconfigs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=openproject.org,resources=serverconfiggrants,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+// Reconcile validates the server and credentials of a ClusterServerConfig
+func (r *ClusterServerConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var cluster openprojectv1alpha1.ClusterServerConfig
+	if err := r.Get(ctx, req.NamespacedName, &cluster); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	logger := ctrl.Log.WithValues("clusterserverconfig", cluster.Name)
+	if debugEnabled {
+		logger = log.FromContext(ctx)
+	}
+	statusLog(logger, "🛠", "Validating ClusterServerConfig", "server", cluster.Spec.Server)
+
+	// The validation works on a ServerConfig reading credentials from the credentials namespace
+	config := configloader.ServerConfigView(&cluster)
+	original := cluster.DeepCopy()
+	wasReady := meta.IsStatusConditionTrue(cluster.Status.Conditions, openprojectv1alpha1.ConditionReady)
+	reason := validateServerConfig(ctx, r.Client, config, logger)
+	cluster.Status = config.Status
+	cluster.Status.ObservedGeneration = cluster.Generation
+
+	// An unchanged result is not written, so periodic validation costs no API writes
+	if !validationChanged(original.Status, cluster.Status) {
+		return validationResult(cluster.Status), nil
+	}
+	cluster.Status.LastValidated = &metav1.Time{Time: time.Now()}
+
+	// Only transitions are worth an event
+	if ready := cluster.Status.Validated; ready != wasReady || len(original.Status.Conditions) == 0 {
+		if ready {
+			r.Recorder.Event(&cluster, corev1.EventTypeNormal, reason, cluster.Status.Message)
+		} else {
+			r.Recorder.Event(&cluster, corev1.EventTypeWarning, reason, cluster.Status.Message)
+		}
+	}
+
+	if err := r.Status().Patch(ctx, &cluster, client.MergeFrom(original)); err != nil {
+		logger.Error(err, "❌ Failed to patch ClusterServerConfig status")
+		return ctrl.Result{}, err
+	}
+
+	return validationResult(cluster.Status), nil
+}
+
+// clusterConfigsReading maps a Secret or ConfigMap to the ClusterServerConfigs reading it from
+// their credentials namespace
+func (r *ClusterServerConfigReconciler) clusterConfigsReading(field string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var configs openprojectv1alpha1.ClusterServerConfigList
+		if err := r.List(ctx, &configs,
+			client.MatchingFields{field: obj.GetNamespace() + "/" + obj.GetName()}); err != nil {
+			ctrl.Log.Error(err, "❌ Unable to list ClusterServerConfigs", "object", obj.GetName())
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(configs.Items))
+		for i := range configs.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&configs.Items[i])})
+		}
+		return requests
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterServerConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Status patches must not trigger another validation; periodic checks use RequeueAfter
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&openprojectv1alpha1.ClusterServerConfig{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		WatchesMetadata(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.clusterConfigsReading(IndexFieldSecretNames))).
+		WatchesMetadata(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.clusterConfigsReading(IndexFieldConfigMapNames))).
+		Complete(r)
+}
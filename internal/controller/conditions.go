@@ -0,0 +1,30 @@
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// newCondition builds a condition; the transition time is filled in by setConditions
+func newCondition(conditionType string, status metav1.ConditionStatus, reason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:    conditionType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+// setConditions merges conditions into a status list, stamping them with the observed generation
+func setConditions(conditions *[]metav1.Condition, generation int64, updates ...metav1.Condition) {
+	for _, c := range updates {
+		c.ObservedGeneration = generation
+		meta.SetStatusCondition(conditions, c)
+	}
+}
+
+// conditionCurrent reports whether a condition is True and was set for the given generation
+func conditionCurrent(conditions []metav1.Condition, conditionType string, generation int64) bool {
+	c := meta.FindStatusCondition(conditions, conditionType)
+	return c != nil && c.Status == metav1.ConditionTrue && c.ObservedGeneration == generation
+}
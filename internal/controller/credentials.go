@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	"github.com/shrapk2/openproject-operator/internal/configloader"
+	"github.com/shrapk2/openproject-operator/internal/openproject"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TokenRefreshMargin is how long before expiry an OAuth2 token is replaced
+var TokenRefreshMargin = getDurationFromEnv("OAUTH2_TOKEN_REFRESH_MARGIN", time.Minute)
+
+// tokenSources caches OAuth2 token sources per ServerConfig so tokens are reused across reconciles
+var tokenSources = &tokenSourceCache{entries: map[string]cachedTokenSource{}}
+
+// cachedTokenSource is a token source and the settings it was built from
+type cachedTokenSource struct {
+	fingerprint string
+	source      oauth2.TokenSource
+}
+
+// tokenSourceCache holds one token source per ServerConfig
+type tokenSourceCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedTokenSource
+}
+
+// get returns the token source of a ServerConfig, replacing it when the settings changed
+func (c *tokenSourceCache) get(key, fingerprint string, build func() oauth2.TokenSource) oauth2.TokenSource {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry, ok := c.entries[key]; ok && entry.fingerprint == fingerprint {
+		return entry.source
+	}
+	source := build()
+	c.entries[key] = cachedTokenSource{fingerprint: fingerprint, source: source}
+	return source
+}
+
+// loadCredentials reads the credentials of a ServerConfig from its Secrets. OAuth2 tokens are
+// fetched with the ServerConfig's http.Client, identified by its fingerprint.
+func loadCredentials(ctx context.Context, c client.Client, config *v1alpha1.ServerConfig, hc *http.Client, transportFingerprint string) (openproject.Credentials, error) {
+	if config.Spec.Auth == nil || config.Spec.Auth.OAuth2ClientCredentials == nil {
+		apiKey, err := configloader.LoadAPIKey(ctx, c, config)
+		if err != nil {
+			return nil, err
+		}
+		return openproject.APIKey(apiKey), nil
+	}
+
+	auth := config.Spec.Auth.OAuth2ClientCredentials
+	clientID, clientSecret, err := configloader.LoadOAuth2Credentials(ctx, c, config)
+	if err != nil {
+		return nil, err
+	}
+
+	// A rotated secret or changed endpoint gets a new token source
+	sum := sha256.Sum256([]byte(strings.Join(append([]string{transportFingerprint, auth.TokenURL, clientID, clientSecret}, auth.Scopes...), "\x00")))
+	source := tokenSources.get(configCacheKey(config), hex.EncodeToString(sum[:]), func() oauth2.TokenSource {
+		cc := clientcredentials.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			TokenURL:     auth.TokenURL,
+			Scopes:       auth.Scopes,
+		}
+		// The source outlives the reconcile, so it must not capture its context
+		tokenCtx := context.WithValue(context.Background(), oauth2.HTTPClient, hc)
+		return oauth2.ReuseTokenSourceWithExpiry(nil, cc.TokenSource(tokenCtx), TokenRefreshMargin)
+	})
+	return openproject.BearerToken{Source: source}, nil
+}
@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	"github.com/shrapk2/openproject-operator/internal/openproject"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLoadCredentials(t *testing.T) {
+	var issued atomic.Int32
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		issued.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer tokenServer.Close()
+
+	secretRef := func(key string) corev1.SecretKeySelector {
+		return corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "oauth"}, Key: key}
+	}
+	config := &v1alpha1.ServerConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "oauth", Namespace: "default"},
+		Spec: v1alpha1.ServerConfigSpec{
+			Auth: &v1alpha1.ServerAuth{OAuth2ClientCredentials: &v1alpha1.OAuth2ClientCredentialsAuth{
+				TokenURL:              tokenServer.URL,
+				ClientIDSecretRef:     secretRef("clientID"),
+				ClientSecretSecretRef: secretRef("clientSecret"),
+			}},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "oauth", Namespace: "default"},
+		Data:       map[string][]byte{"clientID": []byte("operator"), "clientSecret": []byte("s3cret")},
+	}
+	r := newTestWorkPackageReconciler(t, secret)
+	ctx := context.Background()
+
+	authorize := func() string {
+		t.Helper()
+		creds, err := loadCredentials(ctx, r.Client, config, httpClient, "")
+		if err != nil {
+			t.Fatalf("loadCredentials() error = %v", err)
+		}
+		header, err := creds.Authorization(ctx)
+		if err != nil {
+			t.Fatalf("Authorization() error = %v", err)
+		}
+		return header
+	}
+
+	// Tokens are reused across reconciles
+	if got := authorize(); got != "Bearer token" {
+		t.Errorf("Authorization() = %q, want %q", got, "Bearer token")
+	}
+	authorize()
+	if n := issued.Load(); n != 1 {
+		t.Errorf("token endpoint called %d times, want 1", n)
+	}
+
+	// A rotated client secret gets a new token source
+	secret.Data["clientSecret"] = []byte("rotated")
+	if err := r.Update(ctx, secret); err != nil {
+		t.Fatal(err)
+	}
+	authorize()
+	if n := issued.Load(); n != 2 {
+		t.Errorf("token endpoint called %d times after rotation, want 2", n)
+	}
+
+	// Without OAuth2 the API key is used
+	apiKeyConfig, apiKeySecret := testServerConfig("default", "https://openproject.example.com")
+	if err := r.Create(ctx, apiKeySecret); err != nil {
+		t.Fatal(err)
+	}
+	creds, err := loadCredentials(ctx, r.Client, apiKeyConfig, httpClient, "")
+	if err != nil {
+		t.Fatalf("loadCredentials() error = %v", err)
+	}
+	if creds != openproject.APIKey("secret") {
+		t.Errorf("loadCredentials() = %#v, want the API key", creds)
+	}
+	if _, err := loadCredentials(ctx, r.Client, &v1alpha1.ServerConfig{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}}, httpClient, ""); err == nil {
+		t.Error("loadCredentials() without credentials should fail")
+	}
+}
@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	"github.com/shrapk2/openproject-operator/internal/openproject"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeRequest is a request received by fakeOpenProject
+type fakeRequest struct {
+	// Key is "METHOD path" with the path relative to /api/v3
+	Key   string
+	Query url.Values
+	Body  string
+}
+
+// fakeOpenProject is an OpenProject API stub serving canned JSON responses keyed by
+// "METHOD path"; unknown requests get a 404 error and keys passed to fail get their status.
+// Every request is recorded.
+type fakeOpenProject struct {
+	URL string
+
+	server    *httptest.Server
+	mu        sync.Mutex
+	responses map[string]string
+	statuses  map[string]int
+	requests  []fakeRequest
+}
+
+func newFakeOpenProject(t *testing.T, responses map[string]string) *fakeOpenProject {
+	t.Helper()
+	f := &fakeOpenProject{responses: responses, statuses: map[string]int{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		key := req.Method + " " + strings.TrimPrefix(req.URL.Path, "/api/v3")
+
+		f.mu.Lock()
+		f.requests = append(f.requests, fakeRequest{Key: key, Query: req.URL.Query(), Body: string(body)})
+		response, ok := f.responses[key]
+		status, failed := f.statuses[key]
+		f.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/hal+json")
+		if failed {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"_type":"Error","errorIdentifier":"urn:openproject-org:api:v3:errors:Failed","message":"failed"}`))
+			return
+		}
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"_type":"Error","errorIdentifier":"urn:openproject-org:api:v3:errors:NotFound","message":"not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	f.server = server
+	f.URL = server.URL
+	return f
+}
+
+// client returns an API client for the fake server that does not retry
+func (f *fakeOpenProject) client() *openproject.Client {
+	c := openproject.NewClient(f.URL, openproject.APIKey("secret"), f.server.Client(), 0)
+	c.Retry = openproject.RetryPolicy{MaxAttempts: 1}
+	return c
+}
+
+// fail makes requests to key return an error with the given status
+func (f *fakeOpenProject) fail(key string, status int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.statuses[key] = status
+}
+
+// received returns the recorded requests
+func (f *fakeOpenProject) received() []fakeRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]fakeRequest(nil), f.requests...)
+}
+
+// writes returns the keys of the recorded requests that change something
+func (f *fakeOpenProject) writes() []string {
+	var writes []string
+	for _, r := range f.received() {
+		if !strings.HasPrefix(r.Key, "GET ") {
+			writes = append(writes, r.Key)
+		}
+	}
+	return writes
+}
+
+// newTestScheme returns a scheme with the core and operator types
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return scheme
+}
+
+// newTestWorkPackageReconciler returns a reconciler backed by a fake client holding objs and a
+// FakeRecorder buffering its events
+func newTestWorkPackageReconciler(t *testing.T, objs ...client.Object) *WorkPackageReconciler {
+	t.Helper()
+	scheme := newTestScheme(t)
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&v1alpha1.WorkPackages{}).
+		Build()
+	return &WorkPackageReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(20)}
+}
+
+// recordedEvents drains the events buffered by a FakeRecorder
+func recordedEvents(recorder record.EventRecorder) []string {
+	fake := recorder.(*record.FakeRecorder)
+	var events []string
+	for {
+		select {
+		case event := <-fake.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+// testServerConfig returns a ServerConfig for the server at url and the Secret holding its API key
+func testServerConfig(namespace, url string) (*v1alpha1.ServerConfig, *corev1.Secret) {
+	config := &v1alpha1.ServerConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "openproject", Namespace: namespace},
+		Spec: v1alpha1.ServerConfigSpec{
+			Server: url,
+			APIKeySecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "openproject"},
+				Key:                  "apiKey",
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "openproject", Namespace: namespace},
+		Data:       map[string][]byte{"apiKey": []byte("secret")},
+	}
+	return config, secret
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	"github.com/shrapk2/openproject-operator/internal/configloader"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// httpClients caches one http.Client per ServerConfig so connections are reused across reconciles
+var httpClients = &httpClientCache{entries: map[string]cachedHTTPClient{}}
+
+// configCacheKey identifies a ServerConfig in the caches; the UID tells a ClusterServerConfig
+// apart from a ServerConfig of the same name in its credentials namespace
+func configCacheKey(config *v1alpha1.ServerConfig) string {
+	return fmt.Sprintf("%s/%s/%s", config.Namespace, config.Name, config.UID)
+}
+
+// cachedHTTPClient is an http.Client and the settings it was built from
+type cachedHTTPClient struct {
+	fingerprint string
+	client      *http.Client
+}
+
+// httpClientCache holds one http.Client per ServerConfig
+type httpClientCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedHTTPClient
+}
+
+// get returns the client of a ServerConfig, rebuilding it when the settings changed
+func (c *httpClientCache) get(key, fingerprint string, build func() (*http.Client, error)) (*http.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry, ok := c.entries[key]; ok && entry.fingerprint == fingerprint {
+		return entry.client, nil
+	}
+	if entry, ok := c.entries[key]; ok {
+		entry.client.CloseIdleConnections()
+	}
+	built, err := build()
+	if err != nil {
+		return nil, err
+	}
+	c.entries[key] = cachedHTTPClient{fingerprint: fingerprint, client: built}
+	return built, nil
+}
+
+// requestTimeout returns the per-request timeout of a ServerConfig
+func requestTimeout(config *v1alpha1.ServerConfig) time.Duration {
+	if config.Spec.Timeout != nil && config.Spec.Timeout.Duration > 0 {
+		return config.Spec.Timeout.Duration
+	}
+	return RequestTimeout
+}
+
+// httpClientFor returns the http.Client for a ServerConfig and a fingerprint of its settings.
+// ServerConfigs without TLS, proxy or timeout settings share the default client.
+func httpClientFor(ctx context.Context, c client.Client, config *v1alpha1.ServerConfig) (*http.Client, string, error) {
+	if config.Spec.TLS == nil && config.Spec.ProxyURL == "" && config.Spec.Timeout == nil {
+		return httpClient, "", nil
+	}
+
+	material, err := configloader.LoadTLSMaterial(ctx, c, config)
+	if err != nil {
+		return nil, "", err
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00", config.Spec.ProxyURL, requestTimeout(config))
+	if material != nil {
+		fmt.Fprintf(h, "%t\x00%s\x00%s\x00%s", config.Spec.TLS.InsecureSkipVerify, material.CABundle, material.CertPEM, material.KeyPEM)
+	}
+	fingerprint := hex.EncodeToString(h.Sum(nil))
+
+	hc, err := httpClients.get(configCacheKey(config), fingerprint, func() (*http.Client, error) {
+		return buildHTTPClient(config, material)
+	})
+	return hc, fingerprint, err
+}
+
+// buildHTTPClient builds an http.Client with the TLS, proxy and timeout settings of a ServerConfig
+func buildHTTPClient(config *v1alpha1.ServerConfig, material *configloader.TLSMaterial) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if config.Spec.ProxyURL != "" {
+		proxy, err := url.Parse(config.Spec.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxyURL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	if material != nil {
+		tlsConfig := &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			// #nosec G402 -- opt-in per ServerConfig for test servers
+			InsecureSkipVerify: config.Spec.TLS.InsecureSkipVerify,
+		}
+		if len(material.CABundle) > 0 {
+			pool, err := x509.SystemCertPool()
+			if err != nil {
+				pool = x509.NewCertPool()
+			}
+			if !pool.AppendCertsFromPEM(material.CABundle) {
+				return nil, fmt.Errorf("CA bundle contains no PEM certificates")
+			}
+			tlsConfig.RootCAs = pool
+		}
+		if len(material.CertPEM) > 0 {
+			cert, err := tls.X509KeyPair(material.CertPEM, material.KeyPEM)
+			if err != nil {
+				return nil, fmt.Errorf("invalid client certificate: %w", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return &http.Client{Transport: transport, Timeout: requestTimeout(config)}, nil
+}
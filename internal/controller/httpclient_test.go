@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestHTTPClientFor(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+	defer server.Close()
+	caBundle := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	caRef := func(name string) *v1alpha1.ServerTLS {
+		return &v1alpha1.ServerTLS{CABundle: &v1alpha1.CABundleSource{ConfigMapRef: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			Key:                  "ca.crt",
+		}}}
+	}
+	configMap := func(name, data string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Data:       map[string]string{"ca.crt": data},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		spec        v1alpha1.ServerConfigSpec
+		wantShared  bool
+		wantTimeout time.Duration
+		wantTrusted bool
+		wantErr     bool
+	}{
+		{
+			name:        "no settings share the default client",
+			wantShared:  true,
+			wantTimeout: httpClient.Timeout,
+		},
+		{
+			name:        "timeout",
+			spec:        v1alpha1.ServerConfigSpec{Timeout: &metav1.Duration{Duration: 5 * time.Second}},
+			wantTimeout: 5 * time.Second,
+		},
+		{
+			name:        "CA bundle",
+			spec:        v1alpha1.ServerConfigSpec{TLS: caRef("ca")},
+			wantTimeout: RequestTimeout,
+			wantTrusted: true,
+		},
+		{
+			name:    "CA bundle without certificates",
+			spec:    v1alpha1.ServerConfigSpec{TLS: caRef("garbage")},
+			wantErr: true,
+		},
+		{
+			name:    "CA bundle missing",
+			spec:    v1alpha1.ServerConfigSpec{TLS: caRef("missing")},
+			wantErr: true,
+		},
+		{
+			name:    "invalid proxy",
+			spec:    v1alpha1.ServerConfigSpec{ProxyURL: "http://proxy:port"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestWorkPackageReconciler(t, configMap("ca", caBundle), configMap("garbage", "not a certificate"))
+			config := &v1alpha1.ServerConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "httpclient-" + tt.name, Namespace: "default"},
+				Spec:       tt.spec,
+			}
+
+			hc, fingerprint, err := httpClientFor(context.Background(), r.Client, config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("httpClientFor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if (hc == httpClient) != tt.wantShared || (fingerprint == "") != tt.wantShared {
+				t.Errorf("httpClientFor() shared = %v with fingerprint %q, want shared %v", hc == httpClient, fingerprint, tt.wantShared)
+			}
+			if hc.Timeout != tt.wantTimeout {
+				t.Errorf("timeout = %s, want %s", hc.Timeout, tt.wantTimeout)
+			}
+			if tt.wantTrusted {
+				resp, err := hc.Get(server.URL)
+				if err != nil {
+					t.Fatalf("request with the CA bundle failed: %v", err)
+				}
+				resp.Body.Close()
+			}
+
+			// The client is reused until the settings change
+			again, _, err := httpClientFor(context.Background(), r.Client, config)
+			if err != nil || again != hc {
+				t.Errorf("second httpClientFor() = %p, %v, want the cached client %p", again, err, hc)
+			}
+			if tt.wantShared {
+				return
+			}
+			config.Spec.Timeout = &metav1.Duration{Duration: time.Minute}
+			changed, changedFingerprint, err := httpClientFor(context.Background(), r.Client, config)
+			if err != nil || changed == hc || changedFingerprint == fingerprint {
+				t.Errorf("httpClientFor() after a settings change returned the old client")
+			}
+		})
+	}
+}
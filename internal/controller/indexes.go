@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	"github.com/shrapk2/openproject-operator/internal/configloader"
+)
+
+// Field indexes used to find the objects that depend on a Secret, ConfigMap or ServerConfig
+const (
+	// IndexFieldSecretNames holds the Secrets an object reads; for a ClusterServerConfig the
+	// values are namespace/name, as its Secrets live in its credentials namespace
+	IndexFieldSecretNames = "spec.secretNames"
+	// IndexFieldConfigMapNames holds the ConfigMaps an object reads, keyed like IndexFieldSecretNames
+	IndexFieldConfigMapNames = "spec.configMapNames"
+	// IndexFieldServerConfigRef holds the serverConfigKey of the config a WorkPackages uses
+	IndexFieldServerConfigRef = "spec.serverConfigRef"
+)
+
+// serverConfigKey identifies a ServerConfig, or a ClusterServerConfig when namespace is empty
+func serverConfigKey(kind, namespace, name string) string {
+	if kind == v1alpha1.ClusterServerConfigKind {
+		return kind + "/" + name
+	}
+	return v1alpha1.ServerConfigKind + "/" + namespace + "/" + name
+}
+
+// workPackagesServerConfigKey is the serverConfigKey of the config a WorkPackages refers to
+func workPackagesServerConfigKey(wp *v1alpha1.WorkPackages) string {
+	ref := wp.Spec.ServerConfigRef
+	namespace := wp.Namespace
+	if ref.Namespace != "" {
+		namespace = ref.Namespace
+	}
+	return serverConfigKey(ref.Kind, namespace, ref.Name)
+}
+
+// inNamespace prefixes names with a namespace
+func inNamespace(namespace string, names []string) []string {
+	keys := make([]string, 0, len(names))
+	for _, name := range names {
+		keys = append(keys, namespace+"/"+name)
+	}
+	return keys
+}
+
+// cloudInventorySecretNames returns the Secrets a CloudInventory reads credentials from
+func cloudInventorySecretNames(ci *v1alpha1.CloudInventory) []string {
+	var names []string
+	if ci.Spec.AWS != nil && ci.Spec.AWS.CredentialsSecretRef != nil {
+		names = append(names, ci.Spec.AWS.CredentialsSecretRef.Name)
+	}
+	if ci.Spec.Kubernetes != nil && ci.Spec.Kubernetes.KubeconfigSecretRef != nil {
+		names = append(names, ci.Spec.Kubernetes.KubeconfigSecretRef.Name)
+	}
+	return names
+}
+
+// SetupIndexes registers the field indexes the controllers list dependent objects with. It must
+// run before the controllers are set up.
+func SetupIndexes(ctx context.Context, mgr ctrl.Manager) error {
+	indexer := mgr.GetFieldIndexer()
+	indexes := []struct {
+		obj     client.Object
+		field   string
+		extract client.IndexerFunc
+	}{
+		{&v1alpha1.CloudInventoryReport{}, IndexFieldSourceRefName, func(obj client.Object) []string {
+			return []string{obj.(*v1alpha1.CloudInventoryReport).Spec.SourceRef.Name}
+		}},
+		{&v1alpha1.WorkPackages{}, IndexFieldServerConfigRef, func(obj client.Object) []string {
+			return []string{workPackagesServerConfigKey(obj.(*v1alpha1.WorkPackages))}
+		}},
+		{&v1alpha1.ServerConfig{}, IndexFieldSecretNames, func(obj client.Object) []string {
+			return configloader.SecretNames(obj.(*v1alpha1.ServerConfig))
+		}},
+		{&v1alpha1.ServerConfig{}, IndexFieldConfigMapNames, func(obj client.Object) []string {
+			return configloader.ConfigMapNames(obj.(*v1alpha1.ServerConfig))
+		}},
+		{&v1alpha1.ClusterServerConfig{}, IndexFieldSecretNames, func(obj client.Object) []string {
+			cluster := obj.(*v1alpha1.ClusterServerConfig)
+			return inNamespace(cluster.Spec.CredentialsNamespace, configloader.SecretNames(configloader.ServerConfigView(cluster)))
+		}},
+		{&v1alpha1.ClusterServerConfig{}, IndexFieldConfigMapNames, func(obj client.Object) []string {
+			cluster := obj.(*v1alpha1.ClusterServerConfig)
+			return inNamespace(cluster.Spec.CredentialsNamespace, configloader.ConfigMapNames(configloader.ServerConfigView(cluster)))
+		}},
+		{&v1alpha1.CloudInventory{}, IndexFieldSecretNames, func(obj client.Object) []string {
+			return cloudInventorySecretNames(obj.(*v1alpha1.CloudInventory))
+		}},
+	}
+
+	for _, index := range indexes {
+		if err := indexer.IndexField(ctx, index.obj, index.field, index.extract); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// serverConfigsReading returns the keys of the ServerConfigs and ClusterServerConfigs that read
+// a Secret or ConfigMap, given the index field for its kind
+func serverConfigsReading(ctx context.Context, c client.Client, field string, obj client.Object) ([]string, error) {
+	var configs v1alpha1.ServerConfigList
+	if err := c.List(ctx, &configs, client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{field: obj.GetName()}); err != nil {
+		return nil, err
+	}
+	var clusters v1alpha1.ClusterServerConfigList
+	if err := c.List(ctx, &clusters,
+		client.MatchingFields{field: obj.GetNamespace() + "/" + obj.GetName()}); err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(configs.Items)+len(clusters.Items))
+	for _, config := range configs.Items {
+		keys = append(keys, serverConfigKey(v1alpha1.ServerConfigKind, config.Namespace, config.Name))
+	}
+	for _, cluster := range clusters.Items {
+		keys = append(keys, serverConfigKey(v1alpha1.ClusterServerConfigKind, "", cluster.Name))
+	}
+	return keys, nil
+}
+
+// workPackagesUsing returns requests for the WorkPackages using any of the configs with the
+// given keys
+func workPackagesUsing(ctx context.Context, c client.Client, keys ...string) ([]reconcile.Request, error) {
+	var requests []reconcile.Request
+	for _, key := range keys {
+		var wps v1alpha1.WorkPackagesList
+		if err := c.List(ctx, &wps, client.MatchingFields{IndexFieldServerConfigRef: key}); err != nil {
+			return nil, err
+		}
+		for i := range wps.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&wps.Items[i])})
+		}
+	}
+	return requests, nil
+}
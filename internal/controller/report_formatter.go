@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
@@ -11,11 +12,64 @@ import (
 	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
 )
 
+// InventoryExport is one file of an inventory export, e.g. the CSV of a single service
+type InventoryExport struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
 // BuildInventoryMarkdownReport generates a markdown report from CloudInventoryReport
 func BuildInventoryMarkdownReport(rep *v1alpha1.CloudInventoryReport) string {
+	report, _ := buildInventoryReport(rep, true)
+	return report
+}
+
+// BuildInventorySummaryReport generates the markdown report without the CSV blocks
+func BuildInventorySummaryReport(rep *v1alpha1.CloudInventoryReport) string {
+	report, _ := buildInventoryReport(rep, false)
+	return report
+}
+
+// BuildInventoryCSVExports returns one CSV file per service in the report
+func BuildInventoryCSVExports(rep *v1alpha1.CloudInventoryReport) []InventoryExport {
+	_, exports := buildInventoryReport(rep, false)
+	return exports
+}
+
+// BuildInventoryJSONExport returns the full report status as JSON
+func BuildInventoryJSONExport(rep *v1alpha1.CloudInventoryReport) (InventoryExport, error) {
+	data, err := json.MarshalIndent(rep.Status, "", "  ")
+	if err != nil {
+		return InventoryExport{}, fmt.Errorf("failed to marshal inventory report: %w", err)
+	}
+	return InventoryExport{
+		FileName:    rep.Name + ".json",
+		ContentType: "application/json",
+		Content:     data,
+	}, nil
+}
+
+// buildInventoryReport renders the markdown report and collects the CSV of each section;
+// inlineCSV also embeds the CSV blocks in the markdown
+func buildInventoryReport(rep *v1alpha1.CloudInventoryReport, inlineCSV bool) (string, []InventoryExport) {
 	var b strings.Builder
+	var exports []InventoryExport
 	b.WriteString("## Cloud Inventory Report\n")
 
+	addCSV := func(section string, csv *strings.Builder) {
+		if inlineCSV {
+			b.WriteString("\n#### CSV Summary\n```csv\n")
+			b.WriteString(csv.String())
+			b.WriteString("```\n")
+		}
+		exports = append(exports, InventoryExport{
+			FileName:    fmt.Sprintf("%s-%s.csv", rep.Name, strings.ToLower(section)),
+			ContentType: "text/csv",
+			Content:     []byte(csv.String()),
+		})
+	}
+
 	// Container images section
 	if len(rep.Status.ContainerImages) > 0 {
 		cluster := rep.Status.ContainerImages[0].Cluster
@@ -33,13 +87,13 @@ func BuildInventoryMarkdownReport(rep *v1alpha1.CloudInventoryReport) string {
 			}
 		}
 
-		b.WriteString("\n#### CSV Summary\n```csv\n")
-		b.WriteString("cluster,image,repository,version,sha\n")
+		var csv strings.Builder
+		csv.WriteString("cluster,image,repository,version,sha\n")
 		for _, img := range rep.Status.ContainerImages {
-			b.WriteString(fmt.Sprintf("%s,%s,%s,%s,%s\n",
+			csv.WriteString(fmt.Sprintf("%s,%s,%s,%s,%s\n",
 				img.Cluster, img.Image, img.Repository, img.Version, img.SHA))
 		}
-		b.WriteString("```\n")
+		addCSV("images", &csv)
 	}
 
 	// Process all services dynamically
@@ -49,25 +103,29 @@ func BuildInventoryMarkdownReport(rep *v1alpha1.CloudInventoryReport) string {
 			b.WriteString(fmt.Sprintf("\n### AWS %s Inventory\n", service.Name))
 
 			// Call the appropriate formatter based on service name
+			var csv strings.Builder
 			switch service.Name {
 			case "EC2":
-				formatEC2Summary(&b, rep)
+				formatEC2Summary(&b, &csv, rep)
 			case "RDS":
-				formatRDSSummary(&b, rep)
+				formatRDSSummary(&b, &csv, rep)
 			case "ELBV2":
-				formatELBV2Summary(&b, rep)
+				formatELBV2Summary(&b, &csv, rep)
 			case "S3":
-				formatS3Summary(&b, rep)
+				formatS3Summary(&b, &csv, rep)
 			case "EIP": // ← new
-				formatEIPSummary(&b, rep)
+				formatEIPSummary(&b, &csv, rep)
 			case "ECR":
-				formatECRSummary(&b, rep)
+				formatECRSummary(&b, &csv, rep)
 			case "NATGateways":
-				formatNATGatewaysSummary(&b, rep)
+				formatNATGatewaysSummary(&b, &csv, rep)
 			case "InternetGateways":
-				formatInternetGatewaysSummary(&b, rep)
+				formatInternetGatewaysSummary(&b, &csv, rep)
 				// Add cases for other services
 			}
+			if csv.Len() > 0 {
+				addCSV(service.Name, &csv)
+			}
 		}
 	}
 
@@ -88,7 +146,7 @@ func BuildInventoryMarkdownReport(rep *v1alpha1.CloudInventoryReport) string {
 		)
 	}
 
-	return b.String()
+	return b.String(), exports
 }
 
 // collectTagKeys collects all unique tag keys from a list of tagged resources
@@ -137,7 +195,7 @@ func writeCSVRowWithTags(b *strings.Builder, baseRow string, tags map[string]str
 }
 
 // formatEC2Summary formats the EC2 instances summary section
-func formatEC2Summary(b *strings.Builder, rep *v1alpha1.CloudInventoryReport) {
+func formatEC2Summary(b, csv *strings.Builder, rep *v1alpha1.CloudInventoryReport) {
 	// Count instances by state
 	total := len(rep.Status.EC2)
 	stateCounts := make(map[string]int)
@@ -168,11 +226,8 @@ func formatEC2Summary(b *strings.Builder, rep *v1alpha1.CloudInventoryReport) {
 	}
 	sortedTagKeys := collectTagKeys(allEC2Tags)
 
-	// Write CSV summary
-	b.WriteString("\n#### CSV Summary\n```csv\n")
-
 	// Create headers with tag columns
-	writeCSVHeaderWithTags(b, "Name,InstanceID,State,Type,AvailabilityZone,Platform,PublicIP,PrivateDNS,PrivateIP,ImageID,VPCID", sortedTagKeys)
+	writeCSVHeaderWithTags(csv, "Name,InstanceID,State,Type,AvailabilityZone,Platform,PublicIP,PrivateDNS,PrivateIP,ImageID,VPCID", sortedTagKeys)
 
 	// Add each instance as a row
 	for _, inst := range rep.Status.EC2 {
@@ -181,14 +236,13 @@ func formatEC2Summary(b *strings.Builder, rep *v1alpha1.CloudInventoryReport) {
 			inst.Name, inst.InstanceID, inst.State, inst.Type, inst.AZ, inst.Platform,
 			inst.PublicIP, inst.PrivateDNS, inst.PrivateIP, inst.ImageID, inst.VPCID)
 
-		writeCSVRowWithTags(b, baseRow, inst.Tags, sortedTagKeys)
+		writeCSVRowWithTags(csv, baseRow, inst.Tags, sortedTagKeys)
 	}
 
-	b.WriteString("```\n")
 }
 
 // formatRDSSummary formats the RDS instances summary section
-func formatRDSSummary(b *strings.Builder, rep *v1alpha1.CloudInventoryReport) {
+func formatRDSSummary(b, csv *strings.Builder, rep *v1alpha1.CloudInventoryReport) {
 	engineCounts := make(map[string]int)
 	statusCounts := make(map[string]int)
 	for _, db := range rep.Status.RDS {
@@ -211,13 +265,10 @@ func formatRDSSummary(b *strings.Builder, rep *v1alpha1.CloudInventoryReport) {
 		allRDSTags = append(allRDSTags, db.Tags)
 	}
 
-	// CSV output
-	b.WriteString("\n#### CSV Summary\n```csv\n")
-
 	baseColumns := "Identifier,Engine,Version,Class,AZ,Status,MultiAZ,Public,StorageType,Allocated,VPCID"
 	if len(allRDSTags) > 0 {
 		sortedTagKeys := collectTagKeys(allRDSTags)
-		writeCSVHeaderWithTags(b, baseColumns, sortedTagKeys)
+		writeCSVHeaderWithTags(csv, baseColumns, sortedTagKeys)
 
 		for _, db := range rep.Status.RDS {
 			baseRow := fmt.Sprintf("%s,%s,%s,%s,%s,%s,%t,%t,%s,%d,%s",
@@ -225,24 +276,23 @@ func formatRDSSummary(b *strings.Builder, rep *v1alpha1.CloudInventoryReport) {
 				db.AvailabilityZone, db.Status, db.MultiAZ, db.PubliclyAccessible,
 				db.StorageType, db.AllocatedStorage, db.VPCID)
 
-			writeCSVRowWithTags(b, baseRow, db.Tags, sortedTagKeys)
+			writeCSVRowWithTags(csv, baseRow, db.Tags, sortedTagKeys)
 		}
 	} else {
 		// No tags available, use simple format
-		b.WriteString(baseColumns + "\n")
+		csv.WriteString(baseColumns + "\n")
 		for _, db := range rep.Status.RDS {
-			b.WriteString(fmt.Sprintf("%s,%s,%s,%s,%s,%s,%t,%t,%s,%d,%s\n",
+			csv.WriteString(fmt.Sprintf("%s,%s,%s,%s,%s,%s,%t,%t,%s,%d,%s\n",
 				db.DBInstanceIdentifier, db.Engine, db.EngineVersion, db.InstanceClass,
 				db.AvailabilityZone, db.Status, db.MultiAZ, db.PubliclyAccessible,
 				db.StorageType, db.AllocatedStorage, db.VPCID))
 		}
 	}
 
-	b.WriteString("```\n")
 }
 
 // formatELBV2Summary formats the ELBv2 summary section
-func formatELBV2Summary(b *strings.Builder, rep *v1alpha1.CloudInventoryReport) {
+func formatELBV2Summary(b, csv *strings.Builder, rep *v1alpha1.CloudInventoryReport) {
 	b.WriteString("#### Summary\n")
 	b.WriteString(fmt.Sprintf("- Total Load Balancers: `%d`\n", len(rep.Status.ELBV2)))
 
@@ -271,13 +321,11 @@ func formatELBV2Summary(b *strings.Builder, rep *v1alpha1.CloudInventoryReport)
 		allELBTags = append(allELBTags, lb.Tags)
 	}
 
-	b.WriteString("\n#### CSV Summary\n```csv\n")
-
 	// If we have tags, use them
 	baseColumns := "Name,ARN,DNSName,Scheme,Type,VPCID,State,IPAddressType,SecurityGroups,Subnets"
 	if len(allELBTags) > 0 {
 		sortedTagKeys := collectTagKeys(allELBTags)
-		writeCSVHeaderWithTags(b, baseColumns, sortedTagKeys)
+		writeCSVHeaderWithTags(csv, baseColumns, sortedTagKeys)
 
 		for _, lb := range rep.Status.ELBV2 {
 			baseRow := fmt.Sprintf("%s,%s,%s,%s,%s,%s,%s,%s,%v,%v",
@@ -285,23 +333,22 @@ func formatELBV2Summary(b *strings.Builder, rep *v1alpha1.CloudInventoryReport)
 				lb.Type, lb.VPCID, lb.State, lb.IPAddressType,
 				strings.Join(lb.SecurityGroups, ","), strings.Join(lb.Subnets, ","))
 
-			writeCSVRowWithTags(b, baseRow, lb.Tags, sortedTagKeys)
+			writeCSVRowWithTags(csv, baseRow, lb.Tags, sortedTagKeys)
 		}
 	} else {
 		// No tags available, use simple format
-		b.WriteString(baseColumns + "\n")
+		csv.WriteString(baseColumns + "\n")
 		for _, lb := range rep.Status.ELBV2 {
-			b.WriteString(fmt.Sprintf("%s,%s,%s,%s,%s,%s,%s,%s,%v,%v\n",
+			csv.WriteString(fmt.Sprintf("%s,%s,%s,%s,%s,%s,%s,%s,%v,%v\n",
 				lb.Name, lb.ARN, lb.DNSName, lb.Scheme,
 				lb.Type, lb.VPCID, lb.State, lb.IPAddressType,
 				strings.Join(lb.SecurityGroups, ","), strings.Join(lb.Subnets, ",")))
 		}
 	}
 
-	b.WriteString("```\n")
 }
 
-func formatS3Summary(b *strings.Builder, rep *v1alpha1.CloudInventoryReport) {
+func formatS3Summary(b, csv *strings.Builder, rep *v1alpha1.CloudInventoryReport) {
 	b.WriteString("#### Summary\n")
 	b.WriteString(fmt.Sprintf("- Total Buckets: `%d`\n", len(rep.Status.S3)))
 	b.WriteString(fmt.Sprintf("- Potentially Public Buckets: `%d`\n",
@@ -321,8 +368,7 @@ func formatS3Summary(b *strings.Builder, rep *v1alpha1.CloudInventoryReport) {
 			bucket.Name, bucket.Region, bucket.BlockAllPublicAccess))
 	}
 
-	b.WriteString("\n#### CSV Summary\n```csv\n")
-	b.WriteString("name,region,BlockAllPublicAccess,")
+	csv.WriteString("name,region,BlockAllPublicAccess,")
 
 	tagCols := collectTagKeys(func() []map[string]string {
 		var all []map[string]string
@@ -331,18 +377,17 @@ func formatS3Summary(b *strings.Builder, rep *v1alpha1.CloudInventoryReport) {
 		}
 		return all
 	}())
-	b.WriteString(strings.Join(tagCols, ",") + "\n")
+	csv.WriteString(strings.Join(tagCols, ",") + "\n")
 	for _, bucket := range rep.Status.S3 {
 		row := fmt.Sprintf("%s,%s,%t", bucket.Name, bucket.Region, bucket.BlockAllPublicAccess)
 		for _, key := range tagCols {
 			row += "," + bucket.Tags[key]
 		}
-		b.WriteString(row + "\n")
+		csv.WriteString(row + "\n")
 	}
-	b.WriteString("```\n")
 }
 
-func formatEIPSummary(b *strings.Builder, rep *v1alpha1.CloudInventoryReport) {
+func formatEIPSummary(b, csv *strings.Builder, rep *v1alpha1.CloudInventoryReport) {
 	total := len(rep.Status.EIP)
 	assoc := 0
 	for _, e := range rep.Status.EIP {
@@ -355,17 +400,15 @@ func formatEIPSummary(b *strings.Builder, rep *v1alpha1.CloudInventoryReport) {
 	b.WriteString(fmt.Sprintf("- Associated: `%d`\n", assoc))
 	b.WriteString(fmt.Sprintf("- Unassociated: `%d`\n", total-assoc))
 
-	b.WriteString("\n#### CSV Summary\n```csv\n")
-	b.WriteString("allocationId,publicIp,domain,instanceId,networkInterfaceId,privateIp\n")
+	csv.WriteString("allocationId,publicIp,domain,instanceId,networkInterfaceId,privateIp\n")
 	for _, e := range rep.Status.EIP {
-		b.WriteString(fmt.Sprintf("%s,%s,%s,%s,%s,%s\n",
+		csv.WriteString(fmt.Sprintf("%s,%s,%s,%s,%s,%s\n",
 			e.AllocationID, e.PublicIP, e.Domain,
 			e.InstanceID, e.NetworkInterfaceID, e.PrivateIP))
 	}
-	b.WriteString("```\n")
 }
 
-func formatECRSummary(b *strings.Builder, rep *v1alpha1.CloudInventoryReport) {
+func formatECRSummary(b, csv *strings.Builder, rep *v1alpha1.CloudInventoryReport) {
 	b.WriteString("#### Summary\n")
 	b.WriteString(fmt.Sprintf("- Total Repositories: `%d`\n", len(rep.Status.ECR)))
 
@@ -374,41 +417,35 @@ func formatECRSummary(b *strings.Builder, rep *v1alpha1.CloudInventoryReport) {
 		b.WriteString(fmt.Sprintf("- `%s` → `%s`\n", e.RepositoryName, e.LatestImageTag))
 	}
 
-	b.WriteString("\n#### CSV Summary\n```csv\n")
-	b.WriteString("registryId,repositoryName,latestImageTag,latestImageDigest\n")
+	csv.WriteString("registryId,repositoryName,latestImageTag,latestImageDigest\n")
 	for _, e := range rep.Status.ECR {
-		b.WriteString(fmt.Sprintf("%s,%s,%s,%s\n",
+		csv.WriteString(fmt.Sprintf("%s,%s,%s,%s\n",
 			e.RegistryID, e.RepositoryName, e.LatestImageTag, e.LatestImageDigest))
 	}
-	b.WriteString("```\n")
 }
 
-func formatNATGatewaysSummary(b *strings.Builder, rep *v1alpha1.CloudInventoryReport) {
+func formatNATGatewaysSummary(b, csv *strings.Builder, rep *v1alpha1.CloudInventoryReport) {
 	total := len(rep.Status.NATGateways)
 	b.WriteString("#### Summary\n")
 	b.WriteString(fmt.Sprintf("- Total NAT Gateways: `%d`\n", total))
 
-	b.WriteString("\n#### CSV Summary\n```csv\n")
-	b.WriteString("natGatewayId,vpcId,subnetId,state\n")
+	csv.WriteString("natGatewayId,vpcId,subnetId,state\n")
 	for _, nat := range rep.Status.NATGateways {
-		b.WriteString(fmt.Sprintf("%s,%s,%s,%s\n",
+		csv.WriteString(fmt.Sprintf("%s,%s,%s,%s\n",
 			nat.NatGatewayId, nat.VpcId, nat.SubnetId, nat.State))
 	}
-	b.WriteString("```\n")
 }
 
-func formatInternetGatewaysSummary(b *strings.Builder, rep *v1alpha1.CloudInventoryReport) {
+func formatInternetGatewaysSummary(b, csv *strings.Builder, rep *v1alpha1.CloudInventoryReport) {
 	total := len(rep.Status.InternetGateways)
 	b.WriteString("#### Summary\n")
 	b.WriteString(fmt.Sprintf("- Total Internet Gateways: `%d`\n", total))
 
-	b.WriteString("\n#### CSV Summary\n```csv\n")
-	b.WriteString("internetGatewayId,attachments\n")
+	csv.WriteString("internetGatewayId,attachments\n")
 	for _, ig := range rep.Status.InternetGateways {
-		b.WriteString(fmt.Sprintf("%s,%s\n",
+		csv.WriteString(fmt.Sprintf("%s,%s\n",
 			ig.InternetGatewayId,
 			strings.Join(ig.Attachments, "|"),
 		))
 	}
-	b.WriteString("```\n")
 }
@@ -2,19 +2,32 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	openprojectv1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	"github.com/shrapk2/openproject-operator/internal/openproject"
 )
 
 type ServerConfigReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 // getScopedServerConfigLogger returns a simplified logger for normal mode or a detailed logger for debug mode
@@ -28,19 +41,17 @@ func getScopedServerConfigLogger(ctx context.Context, config *openprojectv1alpha
 	return ctrl.Log.WithValues("serverconfig", config.Name)
 }
 
+// ServerConfigValidationInterval is how often a ServerConfig is re-validated
+var ServerConfigValidationInterval = getDurationFromEnv("SERVERCONFIG_VALIDATION_INTERVAL", 10*time.Minute)
+
 // +kubebuilder:rbac:groups=openproject.org,resources=serverconfigs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=openproject.org,resources=serverconfigs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=openproject.org,resources=serverconfigs/finalizers,verbs=update
-func (r *ServerConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	// List all ServerConfigs in the same namespace
-	var configs openprojectv1alpha1.ServerConfigList
-	if err := r.List(ctx, &configs, client.InNamespace(req.Namespace)); err != nil {
-		// Create a minimal logger for error reporting when we can't get the config
-		errorLog := ctrl.Log.WithValues("serverconfig", req.NamespacedName.String())
-		errorLog.Error(err, "❌ Unable to list ServerConfigs")
-		return ctrl.Result{}, err
-	}
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 
+// Reconcile validates the server and credentials of a ServerConfig
+func (r *ServerConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	// Get the current ServerConfig
 	var config openprojectv1alpha1.ServerConfig
 	if err := r.Get(ctx, req.NamespacedName, &config); err != nil {
@@ -49,16 +60,131 @@ func (r *ServerConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 	// Get a scoped logger for this ServerConfig
 	log := getScopedServerConfigLogger(ctx, &config)
+	statusLog(log, "🛠", "Validating ServerConfig", "server", config.Spec.Server)
 
-	// Log that the ServerConfig was loaded
-	statusLog(log, "🛠", "ServerConfig loaded", "server", config.Spec.Server)
+	original := config.DeepCopy()
+	wasReady := meta.IsStatusConditionTrue(config.Status.Conditions, openprojectv1alpha1.ConditionReady)
+	reason := validateServerConfig(ctx, r.Client, &config, log)
+	config.Status.ObservedGeneration = config.Generation
+
+	// An unchanged result is not written, so periodic validation costs no API writes
+	if !validationChanged(original.Status, config.Status) {
+		return validationResult(config.Status), nil
+	}
+	config.Status.LastValidated = &metav1.Time{Time: time.Now()}
+
+	// Only transitions are worth an event
+	if ready := config.Status.Validated; ready != wasReady || len(original.Status.Conditions) == 0 {
+		if ready {
+			r.Recorder.Event(&config, corev1.EventTypeNormal, reason, config.Status.Message)
+		} else {
+			r.Recorder.Event(&config, corev1.EventTypeWarning, reason, config.Status.Message)
+		}
+	}
 
-	return ctrl.Result{}, nil
+	if err := r.Status().Patch(ctx, &config, client.MergeFrom(original)); err != nil {
+		log.Error(err, "❌ Failed to patch ServerConfig status")
+		return ctrl.Result{}, err
+	}
+
+	return validationResult(config.Status), nil
+}
+
+// validationChanged reports whether a validation changed the status, apart from when it ran
+func validationChanged(before, after openprojectv1alpha1.ServerConfigStatus) bool {
+	before.LastValidated, after.LastValidated = nil, nil
+	return !equality.Semantic.DeepEqual(before, after)
+}
+
+// validationResult schedules the next validation, sooner while it fails
+func validationResult(status openprojectv1alpha1.ServerConfigStatus) ctrl.Result {
+	if !status.Validated {
+		return ctrl.Result{RequeueAfter: ShortRequeueTime}
+	}
+	return ctrl.Result{RequeueAfter: ServerConfigValidationInterval}
+}
+
+// validateServerConfig loads the credentials and checks them and the server, recording the
+// outcome in status, and returns the reason of the Ready condition
+func validateServerConfig(ctx context.Context, c client.Client, config *openprojectv1alpha1.ServerConfig, log logr.Logger) string {
+	fail := func(conditionType, reason string, err error, extra ...metav1.Condition) string {
+		statusLog(log, "❌", "ServerConfig validation failed", "reason", reason, "error", err.Error())
+		config.Status.Validated = false
+		config.Status.Message = err.Error()
+		setConditions(&config.Status.Conditions, config.Generation, append(extra,
+			newCondition(conditionType, metav1.ConditionFalse, reason, err.Error()),
+			newCondition(openprojectv1alpha1.ConditionReady, metav1.ConditionFalse, reason, err.Error()))...)
+		return reason
+	}
+
+	op, err := newOpenProjectClient(ctx, c, config)
+	if err != nil {
+		return fail(openprojectv1alpha1.ConditionCredentialsValid, openprojectv1alpha1.ReasonSecretNotFound, err)
+	}
+
+	root, err := op.Root(ctx)
+	if err != nil {
+		if openproject.IsUnauthorized(err) {
+			return fail(openprojectv1alpha1.ConditionCredentialsValid, openprojectv1alpha1.ReasonUnauthorized, err,
+				newCondition(openprojectv1alpha1.ConditionServerReachable, metav1.ConditionTrue, openprojectv1alpha1.ReasonResponseReceived, "OpenProject answered"))
+		}
+		return fail(openprojectv1alpha1.ConditionServerReachable, openprojectv1alpha1.ReasonRequestFailed, err)
+	}
+	config.Status.InstanceName = root.InstanceName
+	config.Status.ServerVersion = root.CoreVersion
+	setConditions(&config.Status.Conditions, config.Generation,
+		newCondition(openprojectv1alpha1.ConditionServerReachable, metav1.ConditionTrue, openprojectv1alpha1.ReasonResponseReceived, "OpenProject answered"))
+
+	user, err := op.CurrentUser(ctx)
+	if err != nil {
+		if openproject.IsUnauthorized(err) {
+			return fail(openprojectv1alpha1.ConditionCredentialsValid, openprojectv1alpha1.ReasonUnauthorized, err)
+		}
+		return fail(openprojectv1alpha1.ConditionServerReachable, openprojectv1alpha1.ReasonRequestFailed, err)
+	}
+	// Servers allowing anonymous access answer for an anonymous user instead of rejecting the key
+	if user.ID == 0 {
+		return fail(openprojectv1alpha1.ConditionCredentialsValid, openprojectv1alpha1.ReasonUnauthorized,
+			fmt.Errorf("the credentials were not accepted; requests are anonymous"))
+	}
+
+	config.Status.AuthenticatedUser = user.Login
+	if config.Status.AuthenticatedUser == "" {
+		config.Status.AuthenticatedUser = user.Name
+	}
+	config.Status.Validated = true
+	config.Status.Message = fmt.Sprintf("Connected to %s %s as %s", root.InstanceName, root.CoreVersion, config.Status.AuthenticatedUser)
+	setConditions(&config.Status.Conditions, config.Generation,
+		newCondition(openprojectv1alpha1.ConditionCredentialsValid, metav1.ConditionTrue, openprojectv1alpha1.ReasonValidated, "Credentials accepted"),
+		newCondition(openprojectv1alpha1.ConditionReady, metav1.ConditionTrue, openprojectv1alpha1.ReasonValidated, config.Status.Message))
+	statusLog(log, "✅", "ServerConfig validated", "version", root.CoreVersion, "user", config.Status.AuthenticatedUser)
+	return openprojectv1alpha1.ReasonValidated
+}
+
+// configsReading maps a Secret or ConfigMap to the ServerConfigs in its namespace that read it
+func (r *ServerConfigReconciler) configsReading(field string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var configs openprojectv1alpha1.ServerConfigList
+		if err := r.List(ctx, &configs, client.InNamespace(obj.GetNamespace()),
+			client.MatchingFields{field: obj.GetName()}); err != nil {
+			ctrl.Log.Error(err, "❌ Unable to list ServerConfigs", "object", obj.GetName())
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(configs.Items))
+		for i := range configs.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&configs.Items[i])})
+		}
+		return requests
+	}
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ServerConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Status patches must not trigger another validation; periodic checks use RequeueAfter
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&openprojectv1alpha1.ServerConfig{}).
+		For(&openprojectv1alpha1.ServerConfig{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		WatchesMetadata(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.configsReading(IndexFieldSecretNames))).
+		WatchesMetadata(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.configsReading(IndexFieldConfigMapNames))).
 		Complete(r)
 }
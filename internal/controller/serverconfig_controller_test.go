@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestServerConfigReconcile(t *testing.T) {
+	root := `{"_type":"Root","instanceName":"OpenProject","coreVersion":"15.4.0"}`
+	me := `{"_type":"User","id":4,"name":"Operator","login":"operator"}`
+
+	tests := []struct {
+		name          string
+		responses     map[string]string
+		failures      map[string]int
+		noSecret      bool
+		wantValidated bool
+		wantReason    string
+		wantReachable metav1.ConditionStatus
+		wantUser      string
+	}{
+		{
+			name:          "valid",
+			responses:     map[string]string{"GET ": root, "GET /users/me": me},
+			wantValidated: true,
+			wantReason:    v1alpha1.ReasonValidated,
+			wantReachable: metav1.ConditionTrue,
+			wantUser:      "operator",
+		},
+		{
+			name:       "secret missing",
+			noSecret:   true,
+			wantReason: v1alpha1.ReasonSecretNotFound,
+		},
+		{
+			name:          "key rejected",
+			failures:      map[string]int{"GET ": http.StatusUnauthorized},
+			wantReason:    v1alpha1.ReasonUnauthorized,
+			wantReachable: metav1.ConditionTrue,
+		},
+		{
+			name:          "anonymous access",
+			responses:     map[string]string{"GET ": root, "GET /users/me": `{"_type":"User","id":0,"name":"Anonymous"}`},
+			wantReason:    v1alpha1.ReasonUnauthorized,
+			wantReachable: metav1.ConditionTrue,
+		},
+		{
+			name:          "not an OpenProject server",
+			wantReason:    v1alpha1.ReasonRequestFailed,
+			wantReachable: metav1.ConditionFalse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeOpenProject(t, tt.responses)
+			for key, status := range tt.failures {
+				f.fail(key, status)
+			}
+			config, secret := testServerConfig("default", f.URL)
+			objs := []client.Object{config}
+			if !tt.noSecret {
+				objs = append(objs, secret)
+			}
+			scheme := newTestScheme(t)
+			recorder := record.NewFakeRecorder(10)
+			r := &ServerConfigReconciler{
+				Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).WithStatusSubresource(config).Build(),
+				Scheme:   scheme,
+				Recorder: recorder,
+			}
+
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(config)}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			var got v1alpha1.ServerConfig
+			if err := r.Get(context.Background(), req.NamespacedName, &got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Status.Validated != tt.wantValidated || got.Status.AuthenticatedUser != tt.wantUser {
+				t.Errorf("validated = %v as %q, want %v as %q", got.Status.Validated, got.Status.AuthenticatedUser, tt.wantValidated, tt.wantUser)
+			}
+			ready := meta.FindStatusCondition(got.Status.Conditions, v1alpha1.ConditionReady)
+			if ready == nil || ready.Reason != tt.wantReason {
+				t.Errorf("Ready condition = %+v, want reason %s", ready, tt.wantReason)
+			}
+			reachable := meta.FindStatusCondition(got.Status.Conditions, v1alpha1.ConditionServerReachable)
+			if tt.wantReachable != "" && (reachable == nil || reachable.Status != tt.wantReachable) {
+				t.Errorf("ServerReachable condition = %+v, want %s", reachable, tt.wantReachable)
+			}
+
+			// The first outcome is announced; repeating it is not
+			if events := recordedEvents(recorder); len(events) != 1 || !strings.Contains(events[0], tt.wantReason) {
+				t.Errorf("events = %v, want one %s", events, tt.wantReason)
+			}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			if events := recordedEvents(recorder); len(events) != 0 {
+				t.Errorf("repeated validation events = %v, want none", events)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+)
+
+// TicketTemplateContext is the data available to WorkPackages subject and description templates
+type TicketTemplateContext struct {
+	// ScheduledTime is the schedule slot the run belongs to
+	ScheduledTime time.Time
+	// Now is the time the ticket is rendered
+	Now time.Time
+	// Year, Month, Quarter and ISOWeek are derived from ScheduledTime
+	Year    int
+	Month   string
+	Quarter int
+	ISOWeek int
+	// Name, Namespace, Labels and Annotations come from the WorkPackages resource
+	Name        string
+	Namespace   string
+	Labels      map[string]string
+	Annotations map[string]string
+	// Summary is the inventory summary map (empty without an inventory)
+	Summary map[string]int
+	// Report is the full CloudInventoryReport, or nil without an inventory
+	Report *v1alpha1.CloudInventoryReport
+}
+
+// newTicketTemplateContext builds the template context for one run of a WorkPackages resource
+func newTicketTemplateContext(wp *v1alpha1.WorkPackages, scheduled time.Time, report *v1alpha1.CloudInventoryReport) TicketTemplateContext {
+	_, week := scheduled.ISOWeek()
+	ctx := TicketTemplateContext{
+		ScheduledTime: scheduled,
+		Now:           time.Now().In(scheduled.Location()),
+		Year:          scheduled.Year(),
+		Month:         scheduled.Month().String(),
+		Quarter:       quarterOf(scheduled),
+		ISOWeek:       week,
+		Name:          wp.Name,
+		Namespace:     wp.Namespace,
+		Labels:        wp.Labels,
+		Annotations:   wp.Annotations,
+		Summary:       map[string]int{},
+		Report:        report,
+	}
+	if report != nil && report.Status.Summary != nil {
+		ctx.Summary = report.Status.Summary
+	}
+	return ctx
+}
+
+// ticketTemplateFuncs are the helper functions available to ticket templates
+var ticketTemplateFuncs = template.FuncMap{
+	// Date math and formatting
+	"date":      func(layout string, t time.Time) string { return t.Format(layout) },
+	"addDays":   func(n int, t time.Time) time.Time { return t.AddDate(0, 0, n) },
+	"addMonths": func(n int, t time.Time) time.Time { return t.AddDate(0, n, 0) },
+	"addYears":  func(n int, t time.Time) time.Time { return t.AddDate(n, 0, 0) },
+	"quarter":   quarterOf,
+	"isoWeek":   func(t time.Time) int { _, w := t.ISOWeek(); return w },
+	"startOfMonth": func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	},
+	"endOfMonth": func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location())
+	},
+
+	// Humanize
+	"humanize":         humanizeInt,
+	"humanizeDuration": humanizeDuration,
+	"pluralize": func(n int, singular, plural string) string {
+		if n == 1 {
+			return singular
+		}
+		return plural
+	},
+
+	// Strings and maps
+	"upper":   strings.ToUpper,
+	"lower":   strings.ToLower,
+	"title":   func(s string) string { return cases.Title(language.English).String(s) },
+	"join":    strings.Join,
+	"default": func(def, v interface{}) interface{} { return defaultValue(def, v) },
+	"total": func(m map[string]int) int {
+		sum := 0
+		for _, v := range m {
+			sum += v
+		}
+		return sum
+	},
+	"table": markdownTable,
+}
+
+// renderTicketTemplate executes a subject or description template against the run context,
+// or a context that embeds it
+func renderTicketTemplate(name, text string, data interface{}) (string, error) {
+	tmpl, err := template.New(name).Funcs(ticketTemplateFuncs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s template: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// isTemplate reports whether text contains template actions
+func isTemplate(text string) bool {
+	return strings.Contains(text, "{{")
+}
+
+// quarterOf returns the calendar quarter (1-4) of t
+func quarterOf(t time.Time) int {
+	return (int(t.Month())-1)/3 + 1
+}
+
+// humanizeInt formats an integer with thousands separators, e.g. 12345 -> "12,345"
+func humanizeInt(n int) string {
+	s := strconv.Itoa(n)
+	sign := ""
+	if n < 0 {
+		sign, s = "-", s[1:]
+	}
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return sign + s
+}
+
+// humanizeDuration formats a duration in its largest sensible unit, e.g. "3 days"
+func humanizeDuration(d time.Duration) string {
+	switch {
+	case d >= 48*time.Hour:
+		return fmt.Sprintf("%d days", int(d.Hours()/24))
+	case d >= 2*time.Hour:
+		return fmt.Sprintf("%d hours", int(d.Hours()))
+	case d >= 2*time.Minute:
+		return fmt.Sprintf("%d minutes", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%d seconds", int(d.Seconds()))
+	}
+}
+
+// defaultValue returns def when v is empty
+func defaultValue(def, v interface{}) interface{} {
+	switch val := v.(type) {
+	case nil:
+		return def
+	case string:
+		if val == "" {
+			return def
+		}
+	case int:
+		if val == 0 {
+			return def
+		}
+	}
+	return v
+}
+
+// markdownTable renders a map as a two-column markdown table sorted by key.
+// Optional headers name the columns, e.g. {{ table .Summary "Service" "Count" }}
+func markdownTable(data interface{}, headers ...string) (string, error) {
+	keyHeader, valueHeader := "Key", "Value"
+	if len(headers) > 0 {
+		keyHeader = headers[0]
+	}
+	if len(headers) > 1 {
+		valueHeader = headers[1]
+	}
+
+	rows := map[string]string{}
+	switch m := data.(type) {
+	case map[string]int:
+		for k, v := range m {
+			rows[k] = humanizeInt(v)
+		}
+	case map[string]string:
+		for k, v := range m {
+			rows[k] = v
+		}
+	default:
+		return "", fmt.Errorf("table: unsupported type %T", data)
+	}
+
+	keys := make([]string, 0, len(rows))
+	for k := range rows {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("| %s | %s |\n|---|---|\n", keyHeader, valueHeader))
+	for _, k := range keys {
+		b.WriteString(fmt.Sprintf("| %s | %s |\n", k, rows[k]))
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,221 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRenderTicketTemplate(t *testing.T) {
+	scheduled := time.Date(2026, 8, 3, 9, 0, 0, 0, time.UTC)
+	wp := &v1alpha1.WorkPackages{ObjectMeta: metav1.ObjectMeta{
+		Name:      "patch-review",
+		Namespace: "team-a",
+		Labels:    map[string]string{"team": "platform"},
+	}}
+	report := &v1alpha1.CloudInventoryReport{Status: v1alpha1.CloudInventoryReportStatus{
+		Summary: map[string]int{"ec2": 42, "rds": 3},
+	}}
+	withReport := newTicketTemplateContext(wp, scheduled, report)
+	withoutReport := newTicketTemplateContext(wp, scheduled, nil)
+
+	tests := []struct {
+		name    string
+		text    string
+		data    TicketTemplateContext
+		want    string
+		wantErr bool
+	}{
+		{name: "plain text", text: "Patch review", data: withReport, want: "Patch review"},
+		{
+			name: "run context",
+			text: "Q{{ .Quarter }} {{ .Year }} – Patch review ({{ .Summary.ec2 }} EC2)",
+			data: withReport,
+			want: "Q3 2026 – Patch review (42 EC2)",
+		},
+		{name: "month and week", text: "{{ .Month }} week {{ .ISOWeek }}", data: withReport, want: "August week 32"},
+		{name: "resource metadata", text: "{{ .Namespace }}/{{ .Name }} {{ .Labels.team }}", data: withReport, want: "team-a/patch-review platform"},
+		{name: "missing key renders empty", text: "[{{ .Labels.owner }}]", data: withReport, want: "[]"},
+		{name: "summary without inventory", text: "{{ total .Summary }} resources", data: withoutReport, want: "0 resources"},
+		{name: "date", text: `{{ date "2006-01-02" .ScheduledTime }}`, data: withReport, want: "2026-08-03"},
+		{name: "addMonths", text: `{{ date "Jan 2006" (addMonths -1 .ScheduledTime) }}`, data: withReport, want: "Jul 2026"},
+		{name: "addDays", text: `{{ date "Jan 2" (addDays 30 .ScheduledTime) }}`, data: withReport, want: "Sep 2"},
+		{name: "endOfMonth", text: `{{ date "Jan 2" (endOfMonth .ScheduledTime) }}`, data: withReport, want: "Aug 31"},
+		{name: "startOfMonth", text: `{{ date "Jan 2 15:04" (startOfMonth .ScheduledTime) }}`, data: withReport, want: "Aug 1 00:00"},
+		{name: "quarter of a shifted date", text: `{{ quarter (addMonths 3 .ScheduledTime) }}`, data: withReport, want: "4"},
+		{name: "humanize", text: "{{ humanize 1234567 }}", data: withReport, want: "1,234,567"},
+		{name: "pluralize", text: `{{ .Summary.rds }} {{ pluralize .Summary.rds "database" "databases" }}`, data: withReport, want: "3 databases"},
+		{name: "title", text: `{{ title "patch review" }}`, data: withReport, want: "Patch Review"},
+		{name: "default", text: `{{ default "unowned" .Labels.owner }}`, data: withReport, want: "unowned"},
+		{name: "total", text: "{{ total .Summary }}", data: withReport, want: "45"},
+		{
+			name: "table",
+			text: `{{ table .Summary "Service" "Count" }}`,
+			data: withReport,
+			want: "| Service | Count |\n|---|---|\n| ec2 | 42 |\n| rds | 3 |\n",
+		},
+		{name: "parse error", text: "{{ .Quarter ", data: withReport, wantErr: true},
+		{name: "unknown function", text: "{{ shout .Name }}", data: withReport, wantErr: true},
+		{name: "execution error", text: "{{ table .Name }}", data: withReport, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderTicketTemplate("subject", tt.text, tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("renderTicketTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("renderTicketTemplate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHumanizeHelpers(t *testing.T) {
+	ints := map[int]string{0: "0", 999: "999", 1000: "1,000", 12345: "12,345", -1234567: "-1,234,567"}
+	for n, want := range ints {
+		if got := humanizeInt(n); got != want {
+			t.Errorf("humanizeInt(%d) = %q, want %q", n, got, want)
+		}
+	}
+
+	durations := map[time.Duration]string{
+		30 * time.Second: "30 seconds",
+		5 * time.Minute:  "5 minutes",
+		3 * time.Hour:    "3 hours",
+		72 * time.Hour:   "3 days",
+	}
+	for d, want := range durations {
+		if got := humanizeDuration(d); got != want {
+			t.Errorf("humanizeDuration(%s) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestMarkdownTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    interface{}
+		headers []string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "default headers",
+			data: map[string]string{"b": "two", "a": "one"},
+			want: "| Key | Value |\n|---|---|\n| a | one |\n| b | two |\n",
+		},
+		{
+			name:    "counts are humanized",
+			data:    map[string]int{"ec2": 1500},
+			headers: []string{"Service"},
+			want:    "| Service | Value |\n|---|---|\n| ec2 | 1,500 |\n",
+		},
+		{
+			name: "empty map",
+			data: map[string]int{},
+			want: "| Key | Value |\n|---|---|\n",
+		},
+		{name: "unsupported type", data: []string{"ec2"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := markdownTable(tt.data, tt.headers...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("markdownTable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("markdownTable() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildTicketPayloadTemplates(t *testing.T) {
+	scheduled := time.Date(2026, 8, 3, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		subject         string
+		description     string
+		template        bool
+		wantSubject     string
+		wantDescription string
+		wantErr         bool
+	}{
+		{
+			name:            "plain subject keeps the month prefix",
+			subject:         "Patch review",
+			description:     "Review the patch level",
+			wantSubject:     "August Patch review",
+			wantDescription: "Review the patch level",
+		},
+		{
+			name:            "templated subject has no prefix",
+			subject:         "Q{{ .Quarter }} {{ .Year }} – Patch review",
+			description:     "Review the patch level",
+			wantSubject:     "Q3 2026 – Patch review",
+			wantDescription: "Review the patch level",
+		},
+		{
+			name:            "templated description",
+			subject:         "Patch review",
+			description:     "Due by {{ date \"January 2\" (endOfMonth .ScheduledTime) }}",
+			template:        true,
+			wantSubject:     "August Patch review",
+			wantDescription: "Due by August 31",
+		},
+		{
+			name:            "description is verbatim without descriptionTemplate",
+			subject:         "Patch review",
+			description:     "Set {{ .Values.image }} in the chart",
+			wantSubject:     "August Patch review",
+			wantDescription: "Set {{ .Values.image }} in the chart",
+		},
+		{
+			name:        "invalid description template",
+			subject:     "Patch review",
+			description: "{{ .Values.image }",
+			template:    true,
+			wantErr:     true,
+		},
+		{
+			name:    "invalid subject template",
+			subject: "{{ .Quarter",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wp := &v1alpha1.WorkPackages{Spec: v1alpha1.WorkPackagesSpec{
+				Subject:             tt.subject,
+				Description:         tt.description,
+				DescriptionTemplate: tt.template,
+			}}
+			r := &WorkPackageReconciler{}
+
+			payload, _, err := r.buildTicketPayload(context.Background(), wp, &ticketTargets{}, ticketRun{Scheduled: scheduled, RunKey: "run-key"}, logr.Discard())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("buildTicketPayload() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := payload["subject"]; got != tt.wantSubject {
+				t.Errorf("subject = %q, want %q", got, tt.wantSubject)
+			}
+			description := payload["description"].(map[string]string)["raw"]
+			if !strings.HasPrefix(description, tt.wantDescription) || !strings.Contains(description, "run-key") {
+				t.Errorf("description = %q, want prefix %q and the run key", description, tt.wantDescription)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	"github.com/shrapk2/openproject-operator/internal/openproject"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// attachInventoryEnabled reports whether the inventory is attached instead of inlined; comments
+// always inline it
+func attachInventoryEnabled(wp *v1alpha1.WorkPackages) bool {
+	return wp.Spec.InventoryAttachment != nil && wp.Spec.InventoryAttachment.Enabled && wp.Spec.Mode != ModeComment
+}
+
+// inventoryExports builds the files for the configured attachment formats
+func inventoryExports(wp *v1alpha1.WorkPackages, report *v1alpha1.CloudInventoryReport) ([]InventoryExport, error) {
+	formats := wp.Spec.InventoryAttachment.Formats
+	if len(formats) == 0 {
+		formats = []v1alpha1.InventoryAttachmentFormat{v1alpha1.InventoryAttachmentCSV, v1alpha1.InventoryAttachmentJSON}
+	}
+
+	var exports []InventoryExport
+	for _, format := range formats {
+		switch format {
+		case v1alpha1.InventoryAttachmentCSV:
+			exports = append(exports, BuildInventoryCSVExports(report)...)
+		case v1alpha1.InventoryAttachmentJSON:
+			export, err := BuildInventoryJSONExport(report)
+			if err != nil {
+				return nil, err
+			}
+			exports = append(exports, export)
+		default:
+			return nil, fmt.Errorf("unknown inventory attachment format %q", format)
+		}
+	}
+	return exports, nil
+}
+
+// attachInventorySection heads the attachment links in a ticket description
+const attachInventorySection = "\n#### Inventory Attachments\n"
+
+// attachInventory uploads the inventory exports to a ticket and links them from its description.
+// Files already attached under the same name, and links already in the description, are kept.
+// Failures are reported but never undo the ticket.
+func (r *WorkPackageReconciler) attachInventory(ctx context.Context, wp *v1alpha1.WorkPackages, op *openproject.Client, ticket *openproject.WorkPackage, report *v1alpha1.CloudInventoryReport, log logr.Logger) {
+	exports, err := inventoryExports(wp, report)
+	if err != nil {
+		r.attachmentFailed(wp, ticket.ID, err, log)
+		return
+	}
+
+	attached, err := op.ListAttachments(ctx, ticket.ID)
+	if err != nil {
+		r.attachmentFailed(wp, ticket.ID, fmt.Errorf("failed to list attachments: %w", err), log)
+		return
+	}
+	byName := make(map[string]openproject.Attachment, len(attached))
+	for _, attachment := range attached {
+		byName[attachment.FileName] = attachment
+	}
+
+	var links strings.Builder
+	uploaded := 0
+	for _, export := range exports {
+		attachment, ok := byName[export.FileName]
+		if !ok {
+			created, err := op.UploadAttachment(ctx, ticket.ID, export.FileName, export.ContentType, export.Content)
+			if err != nil {
+				r.attachmentFailed(wp, ticket.ID, err, log)
+				continue
+			}
+			attachment = *created
+			uploaded++
+		}
+		href := openproject.Href("attachments", attachment.ID) + "/content"
+		if attachment.Links.DownloadLocation != nil && attachment.Links.DownloadLocation.Href != "" {
+			href = attachment.Links.DownloadLocation.Href
+		}
+		links.WriteString(fmt.Sprintf("- [%s](%s)\n", attachment.FileName, href))
+	}
+	if links.Len() == 0 {
+		return
+	}
+	if uploaded > 0 {
+		statusLog(log, "📎", "Attached inventory exports", "ticketID", ticket.ID, "files", uploaded)
+	}
+
+	// Link the files from the description, keeping the run key marker last
+	if ticket.Description == nil || strings.Contains(ticket.Description.Raw, attachInventorySection) {
+		return
+	}
+	description := ticket.Description.Raw
+	section := attachInventorySection + links.String()
+	if i := strings.LastIndex(description, "\n\n---\n_openproject-operator run key:"); i >= 0 {
+		description = description[:i] + "\n" + section + description[i:]
+	} else {
+		description += "\n" + section
+	}
+
+	update := openproject.WorkPackage{
+		LockVersion: ticket.LockVersion,
+		Description: openproject.Markdown(description),
+	}
+	if _, err := op.UpdateWorkPackage(ctx, ticket.ID, update); err != nil {
+		r.attachmentFailed(wp, ticket.ID, fmt.Errorf("failed to link attachments: %w", err), log)
+	}
+}
+
+// attachmentFailed reports a failed upload
+func (r *WorkPackageReconciler) attachmentFailed(wp *v1alpha1.WorkPackages, ticketID int, err error, log logr.Logger) {
+	log.Error(err, "❌ Failed to attach inventory", "ticketID", ticketID)
+	r.Recorder.Eventf(wp, corev1.EventTypeWarning, v1alpha1.ReasonAttachmentFailed,
+		"Failed to attach inventory to ticket #%d: %v", ticketID, err)
+}
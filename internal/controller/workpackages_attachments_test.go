@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	"github.com/shrapk2/openproject-operator/internal/openproject"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAttachInventory(t *testing.T) {
+	uploaded := `{"id":5,"fileName":"inventory.json","_links":{"downloadLocation":{"href":"/api/v3/attachments/5/content"}}}`
+	noAttachments := `{"total":0,"count":0,"_embedded":{"elements":[]}}`
+
+	tests := []struct {
+		name       string
+		formats    []v1alpha1.InventoryAttachmentFormat
+		responses  map[string]string
+		wantWrites []string
+		wantLink   bool
+		wantEvent  bool
+	}{
+		{
+			name:    "uploaded and linked",
+			formats: []v1alpha1.InventoryAttachmentFormat{v1alpha1.InventoryAttachmentJSON},
+			responses: map[string]string{
+				"GET /work_packages/42/attachments":  noAttachments,
+				"POST /work_packages/42/attachments": uploaded,
+				"PATCH /work_packages/42":            `{"id":42}`,
+			},
+			wantWrites: []string{"POST /work_packages/42/attachments", "PATCH /work_packages/42"},
+			wantLink:   true,
+		},
+		{
+			name:    "already attached",
+			formats: []v1alpha1.InventoryAttachmentFormat{v1alpha1.InventoryAttachmentJSON},
+			responses: map[string]string{
+				"GET /work_packages/42/attachments": `{"total":1,"count":1,"_embedded":{"elements":[` + uploaded + `]}}`,
+				"PATCH /work_packages/42":           `{"id":42}`,
+			},
+			wantWrites: []string{"PATCH /work_packages/42"},
+			wantLink:   true,
+		},
+		{
+			name:       "upload fails",
+			formats:    []v1alpha1.InventoryAttachmentFormat{v1alpha1.InventoryAttachmentJSON},
+			responses:  map[string]string{"GET /work_packages/42/attachments": noAttachments},
+			wantWrites: []string{"POST /work_packages/42/attachments"},
+			wantEvent:  true,
+		},
+		{
+			name:      "attachments cannot be listed",
+			formats:   []v1alpha1.InventoryAttachmentFormat{v1alpha1.InventoryAttachmentJSON},
+			wantEvent: true,
+		},
+		{
+			name:      "unknown format",
+			formats:   []v1alpha1.InventoryAttachmentFormat{"xlsx"},
+			wantEvent: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeOpenProject(t, tt.responses)
+			r := newTestWorkPackageReconciler(t)
+			wp := &v1alpha1.WorkPackages{Spec: v1alpha1.WorkPackagesSpec{
+				InventoryAttachment: &v1alpha1.InventoryAttachmentSpec{Enabled: true, Formats: tt.formats},
+			}}
+			report := &v1alpha1.CloudInventoryReport{ObjectMeta: metav1.ObjectMeta{Name: "inventory"}}
+			created := &openproject.WorkPackage{
+				ID:          42,
+				LockVersion: 1,
+				Description: openproject.Markdown("Patch review" + runKeyMarker("8f0c-20261001T0700Z")),
+			}
+
+			r.attachInventory(context.Background(), wp, f.client(), created, report, logr.Discard())
+
+			writes := f.writes()
+			if !slices.Equal(writes, tt.wantWrites) {
+				t.Errorf("attachInventory() writes = %v, want %v", writes, tt.wantWrites)
+			}
+			if tt.wantLink {
+				body := f.received()[len(f.received())-1].Body
+				link := strings.Index(body, "[inventory.json](/api/v3/attachments/5/content)")
+				marker := strings.Index(body, "openproject-operator run key")
+				if link < 0 || marker < link {
+					t.Errorf("description update %s, want the attachment link before the run key marker", body)
+				}
+			}
+			events := recordedEvents(r.Recorder)
+			if gotEvent := len(events) > 0 && strings.Contains(events[0], v1alpha1.ReasonAttachmentFailed); gotEvent != tt.wantEvent || len(events) > 1 {
+				t.Errorf("events = %v, want AttachmentFailed %v", events, tt.wantEvent)
+			}
+		})
+	}
+}
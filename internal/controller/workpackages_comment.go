@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	"github.com/shrapk2/openproject-operator/internal/openproject"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// findCommentByRunKey searches a ticket's activity for a comment already posted for the run key
+func findCommentByRunKey(ctx context.Context, op *openproject.Client, ticketID int, runKey string) (int, error) {
+	activities, err := op.ListActivities(ctx, ticketID)
+	if err != nil {
+		return 0, err
+	}
+	for _, activity := range activities {
+		if activity.Comment != nil && strings.Contains(activity.Comment.Raw, runKey) {
+			return activity.ID, nil
+		}
+	}
+	return 0, nil
+}
+
+// handleComment posts the rendered description of a run as a comment on the target ticket
+func (r *WorkPackageReconciler) handleComment(ctx context.Context, wp *v1alpha1.WorkPackages, op *openproject.Client, targets *ticketTargets, run ticketRun, record v1alpha1.WorkPackageRunRecord, log logr.Logger) (ctrl.Result, error) {
+	targetID := targets.TargetWorkPackageID
+	record.TicketID = strconv.Itoa(targetID)
+	record.TicketURL = op.WorkPackageURL(targetID)
+
+	// A previous attempt may have posted the comment before its status patch was lost
+	existingID, err := findCommentByRunKey(ctx, op, targetID, run.RunKey)
+	if err != nil {
+		return r.handleRequestFailure(ctx, wp, run, record, err, log)
+	}
+	if existingID != 0 {
+		record.CommentID = strconv.Itoa(existingID)
+		completeRunRecord(&record, RunOutcomeExisting, "Comment already exists for this run")
+		return r.recordCreatedTicket(ctx, wp, record.TicketID, run, record, v1alpha1.ReasonTicketExists, log)
+	}
+
+	statusLog(log, "💬", "Commenting on ticket", "ticketID", targetID, "runKey", run.RunKey)
+
+	payload, report, err := r.buildTicketPayload(ctx, wp, targets, run, log)
+	if report != nil {
+		record.InventoryReport = report.Name
+	}
+	if err != nil {
+		log.Error(err, "❌ Failed to build ticket payload")
+		return r.handleRunFailure(ctx, wp, run, record, err, log)
+	}
+	comment := payload["description"].(map[string]string)["raw"]
+
+	if debugEnabled {
+		jsonData, _ := json.Marshal(comment)
+		statusLog(log, "🐞", "Comment", "json", string(jsonData))
+	}
+
+	activity, err := op.AddComment(openproject.WithAttemptCounter(ctx, &record.Attempts), targetID, comment)
+	if err != nil {
+		return r.handleRequestFailure(ctx, wp, run, record, err, log)
+	}
+
+	record.HTTPStatus = http.StatusCreated
+	record.CommentID = strconv.Itoa(activity.ID)
+	completeRunRecord(&record, RunOutcomeCommented, "Comment successfully added")
+	return r.recordCreatedTicket(ctx, wp, record.TicketID, run, record, v1alpha1.ReasonCommentAdded, log)
+}
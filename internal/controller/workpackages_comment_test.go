@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestHandleComment(t *testing.T) {
+	runKey := "8f0c-20261001T0700Z"
+	activities := func(comments ...string) string {
+		elements := make([]string, 0, len(comments))
+		for i, comment := range comments {
+			elements = append(elements, fmt.Sprintf(`{"id":%d,"comment":{"format":"markdown","raw":%q}}`, i+1, comment))
+		}
+		return fmt.Sprintf(`{"total":%d,"count":%d,"_embedded":{"elements":[%s]}}`, len(comments), len(comments), strings.Join(elements, ","))
+	}
+
+	tests := []struct {
+		name        string
+		responses   map[string]string
+		wantWrites  []string
+		wantOutcome string
+		wantComment string
+	}{
+		{
+			name: "new comment",
+			responses: map[string]string{
+				"GET /work_packages/42/activities":  activities("Earlier run `8f0c-20260901T0700Z`"),
+				"POST /work_packages/42/activities": `{"id":9}`,
+			},
+			wantWrites:  []string{"POST /work_packages/42/activities"},
+			wantOutcome: RunOutcomeCommented,
+			wantComment: "9",
+		},
+		{
+			name: "comment already posted for the run",
+			responses: map[string]string{
+				"GET /work_packages/42/activities": activities("Earlier run", "This run `"+runKey+"`"),
+			},
+			wantOutcome: RunOutcomeExisting,
+			wantComment: "2",
+		},
+		{
+			name: "posting fails",
+			responses: map[string]string{
+				"GET /work_packages/42/activities": activities(),
+			},
+			wantWrites:  []string{"POST /work_packages/42/activities"},
+			wantOutcome: RunOutcomeFailed,
+		},
+		{
+			name:        "activities cannot be listed",
+			wantOutcome: RunOutcomeFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeOpenProject(t, tt.responses)
+			wp := &v1alpha1.WorkPackages{
+				ObjectMeta: metav1.ObjectMeta{Name: "patching", Namespace: "default", UID: "8f0c"},
+				Spec: v1alpha1.WorkPackagesSpec{
+					Schedule:    "0 7 * * *",
+					Subject:     "Patch review",
+					Description: "Monthly patching",
+					Mode:        ModeComment,
+				},
+			}
+			r := newTestWorkPackageReconciler(t, wp)
+			run := ticketRun{RunKey: runKey}
+			targets := &ticketTargets{TargetWorkPackageID: 42}
+
+			if _, err := r.handleComment(context.Background(), wp, f.client(), targets, run, newRunRecord(run, metav1.Now().Time), logr.Discard()); err != nil {
+				t.Fatalf("handleComment() error = %v", err)
+			}
+
+			if writes := f.writes(); !slices.Equal(writes, tt.wantWrites) {
+				t.Errorf("handleComment() writes = %v, want %v", writes, tt.wantWrites)
+			}
+			for _, req := range f.received() {
+				if req.Key == "POST /work_packages/42/activities" && !strings.Contains(req.Body, runKey) {
+					t.Errorf("comment %s does not carry the run key", req.Body)
+				}
+			}
+
+			var got v1alpha1.WorkPackages
+			if err := r.Get(context.Background(), client.ObjectKeyFromObject(wp), &got); err != nil {
+				t.Fatal(err)
+			}
+			if len(got.Status.History) != 1 {
+				t.Fatalf("history has %d runs, want 1", len(got.Status.History))
+			}
+			latest := got.Status.History[0]
+			if latest.Outcome != tt.wantOutcome || latest.CommentID != tt.wantComment || latest.TicketID != "42" {
+				t.Errorf("latest run = %s comment %q on #%s, want %s comment %q on #42",
+					latest.Outcome, latest.CommentID, latest.TicketID, tt.wantOutcome, tt.wantComment)
+			}
+			// The ticket commented on is tracked apart from the tickets of the runs
+			if got.Status.TicketID != "" {
+				t.Errorf("status.ticketID = %q, want none in comment mode", got.Status.TicketID)
+			}
+			if tt.wantComment != "" && got.Status.CommentTicketID != "42" {
+				t.Errorf("status.commentTicketID = %q, want 42", got.Status.CommentTicketID)
+			}
+		})
+	}
+}
@@ -1,14 +1,13 @@
 package controller
 
 import (
-	"bytes"
 	"context"
-	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,11 +15,17 @@ import (
 	"github.com/robfig/cron/v3"
 	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
 	"github.com/shrapk2/openproject-operator/internal/configloader"
+	"github.com/shrapk2/openproject-operator/internal/openproject"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
 // Constants for status values
@@ -28,9 +33,29 @@ const (
 	StatusScheduled = "Scheduled"
 	StatusCreated   = "Created"
 	StatusFailed    = "Failed"
+	StatusSuspended = "Suspended"
+	StatusDryRun    = "DryRun"
 )
 
-// Constants for index field source reference names
+// Constants for run history outcomes
+const (
+	RunOutcomeCreated   = "Created"
+	RunOutcomeCommented = "Commented"
+	RunOutcomeDryRun    = "DryRun"
+	RunOutcomeExisting  = "Existing"
+	RunOutcomeFailed    = "Failed"
+)
+
+// Constants for WorkPackages modes
+const (
+	ModeCreate  = "create"
+	ModeComment = "comment"
+)
+
+// DefaultHistoryLimit is the number of runs kept when spec.historyLimit is unset
+const DefaultHistoryLimit = 10
+
+// IndexFieldSourceRefName indexes CloudInventoryReports by the CloudInventory they came from
 const IndexFieldSourceRefName = "spec.sourceRef.name"
 
 var (
@@ -42,6 +67,14 @@ var (
 	ShortRequeueTime   = getDurationFromEnv("SHORT_REQUEUE_TIME", time.Second*30)
 	RequestTimeout     = getDurationFromEnv("REQUEST_TIMEOUT", time.Second*90)
 
+	// Retries of failed OpenProject requests within one reconcile
+	RetryMaxAttempts = getIntFromEnv("RETRY_MAX_ATTEMPTS", openproject.DefaultRetryPolicy.MaxAttempts)
+	RetryBaseDelay   = getDurationFromEnv("RETRY_BASE_DELAY", openproject.DefaultRetryPolicy.BaseDelay)
+	RetryMaxDelay    = getDurationFromEnv("RETRY_MAX_DELAY", openproject.DefaultRetryPolicy.MaxDelay)
+
+	// Reconciles that retry a scheduled run after transient failures before it is given up
+	RunMaxRetries = getIntFromEnv("RUN_MAX_RETRIES", 5)
+
 	// Reusable HTTP client
 	httpClient = &http.Client{Timeout: RequestTimeout}
 )
@@ -64,6 +97,23 @@ func getDurationFromEnv(key string, defaultValue time.Duration) time.Duration {
 	return duration
 }
 
+// getIntFromEnv reads an integer from an environment variable with a default fallback
+func getIntFromEnv(key string, defaultValue int) int {
+	envValue := os.Getenv(key)
+	if envValue == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.Atoi(envValue)
+	if err != nil {
+		fmt.Printf("❌ Invalid integer for %s: %s. Using default: %d\n",
+			key, envValue, defaultValue)
+		return defaultValue
+	}
+
+	return value
+}
+
 // getScopedLogger returns a simplified logger for normal mode or a detailed logger for debug mode
 func getScopedLogger(ctx context.Context, wp *v1alpha1.WorkPackages) logr.Logger {
 	if debugEnabled {
@@ -89,17 +139,30 @@ func statusLog(l logr.Logger, statusEmoji string, message string, keysAndValues
 
 // WorkPackageStatusUpdate represents a status update operation
 type WorkPackageStatusUpdate struct {
-	LastRunTime *metav1.Time
-	NextRunTime *metav1.Time
-	TicketID    string
-	Status      string
-	Message     string
+	LastRunTime       *metav1.Time
+	NextRunTime       *metav1.Time
+	LastScheduleTime  *metav1.Time
+	ActiveRun         *v1alpha1.WorkPackageRun
+	ClearActiveRun    bool
+	TicketID          string
+	CommentTicketID   string
+	CommentID         string
+	RunKey            string
+	RunNowHandled     string
+	ResolvedProjectID int
+	ResolvedTypeID    int
+	HistoryEntry      *v1alpha1.WorkPackageRunRecord
+	DryRun            *v1alpha1.DryRunResult
+	Conditions        []metav1.Condition
+	Status            string
+	Message           string
 }
 
 // WorkPackageReconciler reconciles a WorkPackages object
 type WorkPackageReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 // maybeBase64 makes a best guess if a string is base64 encoded
@@ -120,46 +183,20 @@ type WorkPackageReconciler struct {
 // 	return true
 // }
 
-// makeOpenProjectRequest creates and executes an OpenProject API request
-func makeOpenProjectRequest(ctx context.Context, method, url, apiKey string, payload []byte) (*http.Response, error) {
-	reqCtx, cancel := context.WithTimeout(ctx, RequestTimeout)
-	defer cancel()
-
-	req, err := http.NewRequestWithContext(reqCtx, method, url, bytes.NewBuffer(payload))
+// newOpenProjectClient returns an API client for a ServerConfig, using its http.Client and credentials
+func newOpenProjectClient(ctx context.Context, c client.Client, config *v1alpha1.ServerConfig) (*openproject.Client, error) {
+	hc, transportFingerprint, err := httpClientFor(ctx, c, config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
-
-	//Try to directly decode the base64 key first
-	decodedBytes, err := base64.StdEncoding.DecodeString(apiKey)
-	if err == nil {
-		// Successfully decoded - it was base64, use the decoded value without any prefix
-		// The key appears to be already in the correct format
-		rawKey := strings.TrimSpace(string(decodedBytes))
-		// Re-encode it properly for the Authorization header
-		authValue := base64.StdEncoding.EncodeToString([]byte("apikey:" + rawKey))
-		req.Header.Set("Authorization", fmt.Sprintf("Basic %s", authValue))
-
-		if debugEnabled {
-			fmt.Printf("API Key was base64, decoded to: %s\n", rawKey)
-			fmt.Printf("Auth header value: %s\n", authValue)
-		}
-
-	} else {
-		// Not base64 or corrupt - use the raw key
-		rawKey := strings.TrimSpace(apiKey)
-		authValue := base64.StdEncoding.EncodeToString([]byte("apikey:" + rawKey))
-		req.Header.Set("Authorization", fmt.Sprintf("Basic %s", authValue))
-
-		if debugEnabled {
-			fmt.Printf("API Key was NOT base64, using raw: %s\n", rawKey)
-			fmt.Printf("Auth header value: %s\n", authValue)
-		}
+	credentials, err := loadCredentials(ctx, c, config, hc, transportFingerprint)
+	if err != nil {
+		return nil, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-
-	return httpClient.Do(req)
+	op := openproject.NewClient(config.Spec.Server, credentials, hc, requestTimeout(config))
+	op.Retry = openproject.RetryPolicy{MaxAttempts: RetryMaxAttempts, BaseDelay: RetryBaseDelay, MaxDelay: RetryMaxDelay}
+	return op, nil
 }
 
 // parseSchedule parses a cron schedule with proper error handling
@@ -168,13 +205,53 @@ func parseSchedule(schedule string) (cron.Schedule, error) {
 	return parser.Parse(schedule)
 }
 
-// calculateNextRunTime calculates the next run time based on a schedule and reference time
-func calculateNextRunTime(schedule string, from time.Time) (time.Time, error) {
+// calculateNextRunTime calculates the next run time based on a schedule and reference time,
+// evaluating the cron fields in loc (the operator's local time when nil)
+func calculateNextRunTime(schedule string, from time.Time, loc *time.Location) (time.Time, error) {
 	spec, err := parseSchedule(schedule)
 	if err != nil {
 		return time.Time{}, err
 	}
-	return spec.Next(from), nil
+	if loc == nil {
+		loc = time.Local
+	}
+	return spec.Next(from.In(loc)), nil
+}
+
+// loadTimeZone returns the location of a spec.timeZone, the operator's local time when empty
+func loadTimeZone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timeZone %q: %w", name, err)
+	}
+	return loc, nil
+}
+
+// scheduleLocation returns the time zone a WorkPackages schedule is evaluated in
+func scheduleLocation(wp *v1alpha1.WorkPackages) (*time.Location, error) {
+	return loadTimeZone(wp.Spec.TimeZone)
+}
+
+// timeZoneName names a schedule's time zone for status; the operator's local zone is shown by
+// its current abbreviation, e.g. UTC, rather than as "Local"
+func timeZoneName(loc *time.Location) string {
+	if loc == time.Local {
+		name, _ := time.Now().In(loc).Zone()
+		return name
+	}
+	return loc.String()
+}
+
+// nextScheduledRun returns the next run of a WorkPackages schedule after from, in its time zone
+func nextScheduledRun(wp *v1alpha1.WorkPackages, from time.Time) (time.Time, error) {
+	loc, err := scheduleLocation(wp)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return calculateNextRunTime(wp.Spec.Schedule, from, loc)
 }
 
 // applyStatusUpdate applies a status update to a WorkPackages resource
@@ -188,19 +265,107 @@ func applyStatusUpdate(ctx context.Context, r *WorkPackageReconciler, wp *v1alph
 	if update.NextRunTime != nil {
 		wp.Status.NextRunTime = update.NextRunTime
 	}
+	if update.LastScheduleTime != nil {
+		wp.Status.LastScheduleTime = update.LastScheduleTime
+	}
+	if update.ActiveRun != nil {
+		wp.Status.ActiveRun = update.ActiveRun
+	}
+	if update.ClearActiveRun {
+		wp.Status.ActiveRun = nil
+	}
 	if update.TicketID != "" {
 		wp.Status.TicketID = update.TicketID
 	}
+	if update.RunKey != "" {
+		wp.Status.LastRunKey = update.RunKey
+	}
+	if update.CommentTicketID != "" {
+		wp.Status.CommentTicketID = update.CommentTicketID
+	}
+	if update.CommentID != "" {
+		wp.Status.CommentID = update.CommentID
+	}
+	if update.RunNowHandled != "" {
+		wp.Status.LastHandledRunNow = update.RunNowHandled
+	}
+	if update.ResolvedProjectID != 0 {
+		wp.Status.ResolvedProjectID = update.ResolvedProjectID
+	}
+	if update.ResolvedTypeID != 0 {
+		wp.Status.ResolvedTypeID = update.ResolvedTypeID
+	}
+	if update.HistoryEntry != nil {
+		wp.Status.History = appendRunHistory(wp.Status.History, *update.HistoryEntry, historyLimit(wp))
+	}
+	if update.DryRun != nil {
+		wp.Status.DryRun = update.DryRun
+	}
 	if update.Status != "" {
 		wp.Status.Status = update.Status
 	}
 	if update.Message != "" {
 		wp.Status.Message = update.Message
 	}
+	if loc, err := scheduleLocation(wp); err == nil {
+		wp.Status.TimeZone = timeZoneName(loc)
+	}
+	setConditions(&wp.Status.Conditions, wp.Generation, update.Conditions...)
+	wp.Status.ObservedGeneration = wp.Generation
 
 	return r.Status().Patch(ctx, wp, client.MergeFrom(original))
 }
 
+// historyLimit returns how many runs a WorkPackages resource keeps in its history
+func historyLimit(wp *v1alpha1.WorkPackages) int {
+	if wp.Spec.HistoryLimit == nil {
+		return DefaultHistoryLimit
+	}
+	return int(*wp.Spec.HistoryLimit)
+}
+
+// appendRunHistory prepends a run to the history and drops the oldest runs beyond limit
+func appendRunHistory(history []v1alpha1.WorkPackageRunRecord, entry v1alpha1.WorkPackageRunRecord, limit int) []v1alpha1.WorkPackageRunRecord {
+	if limit <= 0 {
+		return nil
+	}
+	history = append([]v1alpha1.WorkPackageRunRecord{entry}, history...)
+	if len(history) > limit {
+		history = history[:limit]
+	}
+	return history
+}
+
+// newRunRecord starts the history entry for a run
+func newRunRecord(run ticketRun, start time.Time) v1alpha1.WorkPackageRunRecord {
+	return v1alpha1.WorkPackageRunRecord{
+		RunKey:        run.RunKey,
+		ScheduledTime: metav1.Time{Time: run.Scheduled},
+		StartTime:     metav1.Time{Time: start},
+	}
+}
+
+// completeRunRecord fills in the outcome and duration of a run
+func completeRunRecord(record *v1alpha1.WorkPackageRunRecord, outcome, message string) *v1alpha1.WorkPackageRunRecord {
+	record.Outcome = outcome
+	record.Message = message
+	record.Duration = &metav1.Duration{Duration: time.Since(record.StartTime.Time).Round(time.Millisecond)}
+	return record
+}
+
+// inventoryCondition reports whether the run found an inventory report; nil without an inventoryRef
+func inventoryCondition(wp *v1alpha1.WorkPackages, record *v1alpha1.WorkPackageRunRecord) []metav1.Condition {
+	if wp.Spec.InventoryRef == nil {
+		return nil
+	}
+	if record.InventoryReport == "" {
+		return []metav1.Condition{newCondition(v1alpha1.ConditionInventoryReady, metav1.ConditionFalse,
+			v1alpha1.ReasonInventoryUnavailable, "No populated inventory report for "+wp.Spec.InventoryRef.Name)}
+	}
+	return []metav1.Condition{newCondition(v1alpha1.ConditionInventoryReady, metav1.ConditionTrue,
+		v1alpha1.ReasonReportPopulated, "Using inventory report "+record.InventoryReport)}
+}
+
 // processAdditionalFields merges additional fields into the payload
 func processAdditionalFields(payload map[string]interface{}, additionalFields v1alpha1.JSON) {
 	// Convert the JSON to a map we can work with
@@ -239,19 +404,42 @@ func processAdditionalFields(payload map[string]interface{}, additionalFields v1
 func (r *WorkPackageReconciler) buildTicketPayload(
 	ctx context.Context,
 	wp *v1alpha1.WorkPackages,
+	targets *ticketTargets,
+	run ticketRun,
 	log logr.Logger,
-) (map[string]interface{}, error) {
+) (map[string]interface{}, *v1alpha1.CloudInventoryReport, error) {
 	var reportMarkdown string
 
 	report, logs, err := r.triggerCloudInventoryScan(ctx, wp, log)
 	if err != nil {
 		log.Error(err, "❌ Cloud inventory scan failed")
 		reportMarkdown = "_Cloud inventory scan failed._\n"
+	} else if report != nil && attachInventoryEnabled(wp) {
+		// The full export is uploaded once the ticket exists
+		reportMarkdown = BuildInventorySummaryReport(report)
 	} else if report != nil {
 		reportMarkdown = BuildInventoryMarkdownReport(report)
 	}
 
+	// Render subject and description templates against this run
+	tmplCtx := newTicketTemplateContext(wp, run.Scheduled, report)
+	subject, err := renderTicketTemplate("subject", wp.Spec.Subject, tmplCtx)
+	if err != nil {
+		return nil, report, err
+	}
+	if !isTemplate(wp.Spec.Subject) {
+		// Plain subjects keep the month prefix they always had
+		subject = fmt.Sprintf("%s %s", run.Scheduled.Format("January"), subject)
+	}
+
+	// Descriptions predate templating and may contain literal {{ }}, so rendering is opt-in
 	fullDescription := wp.Spec.Description
+	if wp.Spec.DescriptionTemplate {
+		fullDescription, err = renderTicketTemplate("description", wp.Spec.Description, tmplCtx)
+		if err != nil {
+			return nil, report, err
+		}
+	}
 
 	if reportMarkdown != "" {
 		fullDescription += fmt.Sprintf("\n\n_Inventory Reference: `%s`_\n", wp.Spec.InventoryRef.Name) + reportMarkdown
@@ -266,19 +454,21 @@ func (r *WorkPackageReconciler) buildTicketPayload(
 		}
 	}
 
+	// Record the run key on the ticket so a retried run can find it
+	fullDescription += runKeyMarker(run.RunKey)
+
 	payload := map[string]interface{}{
-		// "subject": wp.Spec.Subject,
-		"subject": fmt.Sprintf("%s %s", time.Now().Format("January"), wp.Spec.Subject),
+		"subject": subject,
 		"description": map[string]string{
 			"format": "markdown",
 			"raw":    fullDescription,
 		},
 		"_links": map[string]interface{}{
 			"project": map[string]string{
-				"href": fmt.Sprintf("/api/v3/projects/%d", wp.Spec.ProjectID),
+				"href": openproject.Href("projects", wp.Status.ResolvedProjectID),
 			},
 			"type": map[string]string{
-				"href": fmt.Sprintf("/api/v3/types/%d", wp.Spec.TypeID),
+				"href": openproject.Href("types", wp.Status.ResolvedTypeID),
 			},
 		},
 	}
@@ -289,30 +479,26 @@ func (r *WorkPackageReconciler) buildTicketPayload(
 		}
 	}
 
-	if len(wp.Spec.AdditionalFields.Raw.Raw) > 0 {
-		processAdditionalFields(payload, wp.Spec.AdditionalFields)
+	if targets.Assignee != nil {
+		payload["_links"].(map[string]interface{})["assignee"] = targets.Assignee
 	}
-
-	return payload, nil
-}
-
-// // extractID extracts the ticket ID from an HTTP response
-func extractID(resp *http.Response) string {
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return ""
+	if targets.Responsible != nil {
+		payload["_links"].(map[string]interface{})["responsible"] = targets.Responsible
 	}
-
-	var result map[string]interface{}
-	if err := json.Unmarshal(body, &result); err != nil {
-		return ""
+	for key, value := range targets.CustomFields {
+		payload[key] = value
+	}
+	for key, link := range targets.CustomFieldLinks {
+		payload["_links"].(map[string]interface{})[key] = link
 	}
 
-	if id, ok := result["id"]; ok {
-		return fmt.Sprintf("%v", id)
+	if len(wp.Spec.AdditionalFields.Raw.Raw) > 0 {
+		processAdditionalFields(payload, wp.Spec.AdditionalFields)
 	}
-	return ""
+
+	return payload, report, nil
 }
+
 func (r *WorkPackageReconciler) triggerCloudInventoryScan(ctx context.Context, wp *v1alpha1.WorkPackages, log logr.Logger) (*v1alpha1.CloudInventoryReport, []string, error) {
 	if wp.Spec.InventoryRef == nil {
 		return nil, nil, nil
@@ -330,25 +516,14 @@ func (r *WorkPackageReconciler) triggerCloudInventoryScan(ctx context.Context, w
 	logs = append(logs, fmt.Sprintf("Found CloudInventory: %s (mode: %s)", inv.Name, inv.Spec.Mode))
 
 	// Prepare the reconciler
-	cloudRec := &CloudInventoryReconciler{Client: r.Client, Scheme: r.Scheme}
-	var err error
+	cloudRec := &CloudInventoryReconciler{Client: r.Client, Scheme: r.Scheme, Recorder: r.Recorder}
 
 	// Dispatch based on Mode or which spec is present
-	switch inv.Spec.Mode {
-	case "aws":
-		_, err = cloudRec.reconcileAWS(ctx, &inv, log)
-	case "kubernetes":
-		_, err = cloudRec.reconcileKubernetes(ctx, &inv, log)
-	default:
-		if inv.Spec.AWS != nil {
-			_, err = cloudRec.reconcileAWS(ctx, &inv, log)
-		} else if inv.Spec.Kubernetes != nil {
-			_, err = cloudRec.reconcileKubernetes(ctx, &inv, log)
-		} else {
-			// No inventory spec defined; skip silently
-			logs = append(logs, "No AWS or Kubernetes inventory spec defined; skipping")
-			return nil, logs, nil
-		}
+	_, err := cloudRec.runInventory(ctx, &inv, log)
+	if errors.Is(err, errNoInventorySpec) {
+		// No inventory spec defined; skip silently
+		logs = append(logs, "No AWS or Kubernetes inventory spec defined; skipping")
+		return nil, logs, nil
 	}
 
 	if err != nil {
@@ -411,31 +586,10 @@ func (r *WorkPackageReconciler) triggerCloudInventoryScan(ctx context.Context, w
 	return latest, logs, nil
 }
 
-// shouldRunNow determines if it's time to create a ticket
-func shouldRunNow(schedule string, lastRun *metav1.Time, creationTime metav1.Time) bool {
-	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
-	spec, err := parser.Parse(schedule)
-	if err != nil {
-		return false
-	}
-	now := time.Now()
-	last := creationTime.Time
-	if lastRun != nil {
-		if !lastRun.IsZero() {
-			last = lastRun.Time
-		} else {
-			// If LastRunTime exists but is zero, this is an initialized resource
-			// waiting for first run - check against NextRunTime instead
-			return true
-		}
-	}
-	return now.After(spec.Next(last))
-}
-
 // handleInitialization initializes a WorkPackages resource
 func (r *WorkPackageReconciler) handleInitialization(ctx context.Context, wp *v1alpha1.WorkPackages, log logr.Logger) (ctrl.Result, error) {
 	now := time.Now()
-	next, err := calculateNextRunTime(wp.Spec.Schedule, now)
+	next, err := nextScheduledRun(wp, now)
 	if err != nil {
 		log.Error(err, "❌ Failed to parse cron schedule")
 		return ctrl.Result{}, err
@@ -444,9 +598,12 @@ func (r *WorkPackageReconciler) handleInitialization(ctx context.Context, wp *v1
 	update := WorkPackageStatusUpdate{
 		NextRunTime: &metav1.Time{Time: next},
 		Status:      StatusScheduled,
-		Message:     "Next run scheduled",
+		Message:     fmt.Sprintf("Next run scheduled for %s", next.Format(time.RFC3339)),
 		// Set an empty LastRunTime to mark as initialized
 		LastRunTime: &metav1.Time{Time: time.Time{}},
+		Conditions: []metav1.Condition{
+			newCondition(v1alpha1.ConditionReady, metav1.ConditionTrue, v1alpha1.ReasonScheduled, "Schedule initialized"),
+		},
 	}
 
 	if err := applyStatusUpdate(ctx, r, wp, update, log); err != nil {
@@ -458,120 +615,335 @@ func (r *WorkPackageReconciler) handleInitialization(ctx context.Context, wp *v1
 	return ctrl.Result{RequeueAfter: DefaultRequeueTime}, nil
 }
 
-// handleCreateTicket creates a ticket in OpenProject
-func (r *WorkPackageReconciler) handleCreateTicket(ctx context.Context, wp *v1alpha1.WorkPackages, config *v1alpha1.ServerConfig, apiKey string, log logr.Logger) (ctrl.Result, error) {
-	statusLog(log, "🔄", "Creating new ticket", "subject", wp.Spec.Subject)
+// handleCreateTicket creates a ticket in OpenProject for a scheduled or manual run
+func (r *WorkPackageReconciler) handleCreateTicket(ctx context.Context, wp *v1alpha1.WorkPackages, op *openproject.Client, targets *ticketTargets, run ticketRun, log logr.Logger) (ctrl.Result, error) {
+	runKey := run.RunKey
+	record := newRunRecord(run, time.Now())
+
+	// Mark a scheduled run as active so an interrupted run is resumed or replaced per the concurrency policy
+	if !run.manual() && (wp.Status.ActiveRun == nil || wp.Status.ActiveRun.RunKey != runKey) {
+		update := WorkPackageStatusUpdate{
+			ActiveRun: &v1alpha1.WorkPackageRun{
+				RunKey:        runKey,
+				ScheduledTime: metav1.Time{Time: run.Scheduled},
+				StartTime:     metav1.Now(),
+			},
+		}
+		if err := applyStatusUpdate(ctx, r, wp, update, log); err != nil {
+			log.Error(err, "❌ Failed to record active run")
+			return ctrl.Result{}, err
+		}
+	}
 
-	// Build the payload
-	payload, err := r.buildTicketPayload(ctx, wp, log)
-	if err != nil {
-		log.Error(err, "❌ Failed to build ticket payload")
-		return ctrl.Result{}, err
+	if wp.Spec.DryRun {
+		return r.handleDryRun(ctx, wp, op, targets, run, record, log)
 	}
-	if err != nil {
-		log.Error(err, "❌ Failed to build ticket payload")
-		return ctrl.Result{}, err
+	if wp.Spec.Mode == ModeComment {
+		return r.handleComment(ctx, wp, op, targets, run, record, log)
 	}
 
-	jsonData, err := json.Marshal(payload)
+	// A previous attempt may have created the ticket before its status patch was lost
+	existing, err := findTicketByRunKey(ctx, op, wp.Status.ResolvedProjectID, runKey)
 	if err != nil {
-		log.Error(err, "❌ Failed to marshal JSON payload")
-		return ctrl.Result{}, err
+		log.Error(err, "❌ Failed to check for an existing ticket", "runKey", runKey)
+		return r.handleRequestFailure(ctx, wp, run, record, err, log)
+	}
+	if existing != nil {
+		// The follow-up steps may not have run; use the latest report rather than scanning again
+		report, _ := r.loadLatestInventoryReport(ctx, wp, log)
+		if report != nil {
+			record.InventoryReport = report.Name
+		}
+		r.completeTicket(ctx, wp, op, targets, run, existing, report, &record, log)
+		completeRunRecord(&record, RunOutcomeExisting, "Ticket already exists for this run")
+		return r.recordCreatedTicket(ctx, wp, record.TicketID, run, record, v1alpha1.ReasonTicketExists, log)
 	}
 
-	// Prepare API request
-	url := fmt.Sprintf("%s/api/v3/work_packages", config.Spec.Server)
+	statusLog(log, "🔄", "Creating new ticket", "subject", wp.Spec.Subject, "runKey", runKey)
+
+	// Build the payload
+	payload, report, err := r.buildTicketPayload(ctx, wp, targets, run, log)
+	if report != nil {
+		record.InventoryReport = report.Name
+	}
+	if err != nil {
+		log.Error(err, "❌ Failed to build ticket payload")
+		return r.handleRunFailure(ctx, wp, run, record, err, log)
+	}
 
 	if debugEnabled {
+		jsonData, _ := json.Marshal(payload)
 		statusLog(log, "🐞", "Request JSON payload", "json", string(jsonData))
-		statusLog(log, "🐞", "POST URL", "url", url)
 	}
 
-	// Send request to OpenProject API
-	resp, err := makeOpenProjectRequest(ctx, "POST", url, apiKey, jsonData)
+	// Send request to OpenProject API; transient failures are retried by the client
+	created, err := op.CreateWorkPackage(openproject.WithAttemptCounter(ctx, &record.Attempts), payload)
 	if err != nil {
-		log.Error(err, "❌ Failed to send request")
-		return ctrl.Result{}, err
+		return r.handleRequestFailure(ctx, wp, run, record, err, log)
 	}
-	defer resp.Body.Close()
 
-	// Handle error responses
-	if resp.StatusCode >= 300 {
-		if debugEnabled {
-			statusLog(log, "⚠", "Non-2xx status from OpenProject",
-				"status", resp.StatusCode,
-				"url", url,
-				"apikey", apiKey)
-		} else {
-			statusLog(log, "⚠", "Non-2xx status from OpenProject", "status", resp.StatusCode)
-		}
-		r.updateFailedStatus(ctx, wp, log)
-		return ctrl.Result{RequeueAfter: DefaultRequeueTime}, nil
+	// Process successful response
+	record.HTTPStatus = http.StatusCreated
+	r.completeTicket(ctx, wp, op, targets, run, created, report, &record, log)
+	completeRunRecord(&record, RunOutcomeCreated, "Ticket successfully created")
+	return r.recordCreatedTicket(ctx, wp, record.TicketID, run, record, v1alpha1.ReasonTicketCreated, log)
+}
+
+// completeTicket runs the steps that follow the creation of a ticket and records the ticket in
+// the run. Each step skips what an earlier attempt already did, so it is also run for a ticket
+// that was found by its run key.
+func (r *WorkPackageReconciler) completeTicket(ctx context.Context, wp *v1alpha1.WorkPackages, op *openproject.Client, targets *ticketTargets, run ticketRun, ticket *openproject.WorkPackage, report *v1alpha1.CloudInventoryReport, record *v1alpha1.WorkPackageRunRecord, log logr.Logger) {
+	if report != nil && attachInventoryEnabled(wp) {
+		r.attachInventory(ctx, wp, op, ticket, report, log)
+	}
+	r.addWatchers(ctx, wp, op, ticket.ID, targets.WatcherIDs, log)
+	record.Relations = r.createRelations(ctx, wp, op, ticket.ID, log)
+	if report != nil && wp.Spec.Findings != nil && wp.Spec.Findings.Enabled {
+		record.Findings = r.createFindings(ctx, wp, op, ticket.ID, run, report, log)
 	}
+	r.handlePreviousTicket(ctx, wp, op, ticket.ID, log)
+	record.TicketID = strconv.Itoa(ticket.ID)
+	record.TicketURL = op.WorkPackageURL(ticket.ID)
+}
 
-	// Process successful response
-	id := extractID(resp)
+// handleRequestFailure records a failed create or comment request and decides whether the run is
+// tried again: soon for transient errors, honoring Retry-After, until RunMaxRetries is reached.
+// Errors that need a change on either side, such as validation errors, end the run.
+func (r *WorkPackageReconciler) handleRequestFailure(ctx context.Context, wp *v1alpha1.WorkPackages, run ticketRun, record v1alpha1.WorkPackageRunRecord, err error, log logr.Logger) (ctrl.Result, error) {
+	record.HTTPStatus = openproject.StatusCode(err)
+	record.ErrorCategory = openproject.Category(err)
+
+	if !openproject.IsTransient(err) {
+		statusLog(log, "⚠", "OpenProject rejected the request", "status", record.HTTPStatus, "category", record.ErrorCategory, "error", err.Error())
+		return r.handleRunFailure(ctx, wp, run, record, err, log)
+	}
+	retries := activeRunRetries(wp, run)
+	if run.manual() || retries >= RunMaxRetries {
+		statusLog(log, "⚠", "OpenProject request failed; giving up the run", "status", record.HTTPStatus,
+			"category", record.ErrorCategory, "retries", retries, "error", err.Error())
+		return r.handleRunFailure(ctx, wp, run, record, err, log)
+	}
+
+	statusLog(log, "⚠", "OpenProject request failed; retrying the run", "status", record.HTTPStatus,
+		"category", record.ErrorCategory, "attempts", record.Attempts, "error", err.Error())
+	r.updateRetryStatus(ctx, wp, run, completeRunRecord(&record, RunOutcomeFailed, err.Error()), retries+1, log)
+	requeue := ShortRequeueTime
+	if retryAfter := openproject.RetryAfter(err); retryAfter > requeue {
+		requeue = retryAfter
+	}
+	return ctrl.Result{RequeueAfter: requeue}, nil
+}
+
+// handleRunFailure ends a run that cannot succeed by being repeated, recording it once in the
+// history; a scheduled run moves on to the next slot
+func (r *WorkPackageReconciler) handleRunFailure(ctx context.Context, wp *v1alpha1.WorkPackages, run ticketRun, record v1alpha1.WorkPackageRunRecord, err error, log logr.Logger) (ctrl.Result, error) {
+	next := r.updateFailedStatus(ctx, wp, run, completeRunRecord(&record, RunOutcomeFailed, err.Error()), log)
+	return ctrl.Result{RequeueAfter: requeueUntil(next)}, nil
+}
+
+// activeRunRetries returns how often the active run was already retried
+func activeRunRetries(wp *v1alpha1.WorkPackages, run ticketRun) int {
+	if active := wp.Status.ActiveRun; active != nil && active.RunKey == run.RunKey {
+		return int(active.Retries)
+	}
+	return 0
+}
+
+// handlePreviousTicket applies the previous ticket policy; failures never undo the new ticket
+func (r *WorkPackageReconciler) handlePreviousTicket(ctx context.Context, wp *v1alpha1.WorkPackages, op *openproject.Client, newID int, log logr.Logger) {
+	if err := applyPreviousTicketPolicy(ctx, wp, op, newID, log); err != nil {
+		log.Error(err, "❌ Failed to apply previous ticket policy",
+			"policy", wp.Spec.PreviousTicketPolicy,
+			"previousTicketID", wp.Status.TicketID)
+		r.Recorder.Eventf(wp, corev1.EventTypeWarning, v1alpha1.ReasonPreviousTicketFailed,
+			"Failed to apply previous ticket policy %q to ticket #%s: %v", wp.Spec.PreviousTicketPolicy, wp.Status.TicketID, err)
+	}
+}
+
+// recordCreatedTicket marks the run as done; scheduled runs also advance the schedule
+func (r *WorkPackageReconciler) recordCreatedTicket(ctx context.Context, wp *v1alpha1.WorkPackages, id string, run ticketRun, record v1alpha1.WorkPackageRunRecord, reason string, log logr.Logger) (ctrl.Result, error) {
 	now := time.Now()
-	next, _ := calculateNextRunTime(wp.Spec.Schedule, now)
+	message := record.Message
+	conditions := []metav1.Condition{
+		newCondition(v1alpha1.ConditionReady, metav1.ConditionTrue, reason, message),
+		newCondition(v1alpha1.ConditionLastRunSucceeded, metav1.ConditionTrue, reason, message),
+		newCondition(v1alpha1.ConditionServerReachable, metav1.ConditionTrue, v1alpha1.ReasonResponseReceived, "OpenProject answered the last request"),
+		newCondition(v1alpha1.ConditionCredentialsValid, metav1.ConditionTrue, v1alpha1.ReasonCredentialsLoaded, "API key accepted"),
+		newCondition(v1alpha1.ConditionDegraded, metav1.ConditionFalse, v1alpha1.ReasonAsExpected, ""),
+	}
 
 	// Update status
 	update := WorkPackageStatusUpdate{
-		LastRunTime: &metav1.Time{Time: now},
-		NextRunTime: &metav1.Time{Time: next},
-		TicketID:    id,
-		Status:      StatusCreated,
-		Message:     "Ticket successfully created",
+		LastRunTime:  &metav1.Time{Time: now},
+		CommentID:    record.CommentID,
+		RunKey:       run.RunKey,
+		HistoryEntry: &record,
+		Conditions:   append(conditions, inventoryCondition(wp, &record)...),
+		Status:       StatusCreated,
+		Message:      message,
 	}
+	// The ticket commented on is not a ticket of this run, so the previous ticket policy must not see it
+	if wp.Spec.Mode == ModeComment {
+		update.CommentTicketID = id
+	} else {
+		update.TicketID = id
+	}
+
+	next := finishRun(wp, run, &update, now)
+
+	r.Recorder.Eventf(wp, corev1.EventTypeNormal, reason, "%s: ticket #%s (run key %s)", message, id, run.RunKey)
 
 	if err := applyStatusUpdate(ctx, r, wp, update, log); err != nil {
 		log.Error(err, "❌ Failed to patch status")
 	} else {
-		statusLog(log, "✅", "Successfully created ticket",
+		statusLog(log, "✅", message,
 			"ticketID", id,
+			"runKey", run.RunKey,
 			"nextRunTime", next.Format(time.RFC3339))
 	}
 
-	return ctrl.Result{RequeueAfter: DefaultRequeueTime}, nil
+	return ctrl.Result{RequeueAfter: requeueUntil(next)}, nil
 }
 
-// updateFailedStatus updates the status to reflect a failed ticket creation
-func (r *WorkPackageReconciler) updateFailedStatus(ctx context.Context, wp *v1alpha1.WorkPackages, log logr.Logger) {
-	now := time.Now()
-	next, err := calculateNextRunTime(wp.Spec.Schedule, now)
-	if err != nil {
-		log.Error(err, "❌ Failed to calculate next run time")
-		return
+// finishRun marks a completed run as handled in update and returns when the next run is due;
+// manual runs leave the schedule untouched
+func finishRun(wp *v1alpha1.WorkPackages, run ticketRun, update *WorkPackageStatusUpdate, now time.Time) time.Time {
+	next := now
+	if wp.Status.NextRunTime != nil {
+		next = wp.Status.NextRunTime.Time
 	}
+	if run.manual() {
+		update.RunNowHandled = run.RunNowToken
+		return next
+	}
+	next, _ = nextScheduledRun(wp, run.Scheduled)
+	update.NextRunTime = &metav1.Time{Time: next}
+	update.LastScheduleTime = &metav1.Time{Time: run.Scheduled}
+	update.ClearActiveRun = true
+	return next
+}
+
+// requeueUntil returns how long to wait for the next slot, checking at least every DefaultRequeueTime
+func requeueUntil(next time.Time) time.Duration {
+	wait := time.Until(next)
+	if wait <= 0 {
+		return time.Second
+	}
+	if wait > DefaultRequeueTime {
+		return DefaultRequeueTime
+	}
+	return wait
+}
 
+// failureConditions returns the conditions describing a failed run
+func failureConditions(wp *v1alpha1.WorkPackages, record *v1alpha1.WorkPackageRunRecord, message string) []metav1.Condition {
+	conditions := []metav1.Condition{
+		newCondition(v1alpha1.ConditionReady, metav1.ConditionFalse, v1alpha1.ReasonTicketCreationFailed, message),
+		newCondition(v1alpha1.ConditionLastRunSucceeded, metav1.ConditionFalse, v1alpha1.ReasonTicketCreationFailed, message),
+		newCondition(v1alpha1.ConditionDegraded, metav1.ConditionTrue, v1alpha1.ReasonTicketCreationFailed, message),
+	}
+	switch {
+	case record.ErrorCategory == "":
+		// The run failed before a request was sent, e.g. on a template error
+	case record.HTTPStatus == 0:
+		conditions = append(conditions,
+			newCondition(v1alpha1.ConditionServerReachable, metav1.ConditionFalse, v1alpha1.ReasonRequestFailed, record.Message))
+	case record.HTTPStatus == http.StatusUnauthorized || record.HTTPStatus == http.StatusForbidden:
+		conditions = append(conditions,
+			newCondition(v1alpha1.ConditionServerReachable, metav1.ConditionTrue, v1alpha1.ReasonResponseReceived, record.Message),
+			newCondition(v1alpha1.ConditionCredentialsValid, metav1.ConditionFalse, v1alpha1.ReasonUnauthorized, record.Message))
+	default:
+		conditions = append(conditions,
+			newCondition(v1alpha1.ConditionServerReachable, metav1.ConditionTrue, v1alpha1.ReasonResponseReceived, record.Message))
+	}
+	return append(conditions, inventoryCondition(wp, record)...)
+}
+
+// updateFailedStatus records a failed run in the status and history and marks it handled: a
+// scheduled run advances the schedule and a manual run is not repeated. It returns when the next
+// run is due.
+func (r *WorkPackageReconciler) updateFailedStatus(ctx context.Context, wp *v1alpha1.WorkPackages, run ticketRun, record *v1alpha1.WorkPackageRunRecord, log logr.Logger) time.Time {
+	message := "Ticket creation failed: " + record.Message
 	update := WorkPackageStatusUpdate{
-		NextRunTime: &metav1.Time{Time: next},
-		Status:      StatusFailed,
-		Message:     "Ticket creation failed",
+		Status:       StatusFailed,
+		Message:      message,
+		HistoryEntry: record,
+		Conditions:   failureConditions(wp, record, message),
 	}
+	next := finishRun(wp, run, &update, time.Now())
+
+	r.Recorder.Eventf(wp, corev1.EventTypeWarning, v1alpha1.ReasonTicketCreationFailed, "%s (run key %s)", message, run.RunKey)
 
 	if err := applyStatusUpdate(ctx, r, wp, update, log); err != nil {
 		log.Error(err, "❌ Failed to update failed status")
 	}
 
-	statusLog(log, "❌", "Ticket creation failed", "nextRetry", next.Format(time.RFC3339))
+	if run.manual() {
+		statusLog(log, "❌", "Manual ticket creation failed", "runNow", run.RunNowToken)
+	} else {
+		statusLog(log, "❌", "Ticket creation failed", "category", record.ErrorCategory, "attempts", record.Attempts,
+			"nextRunTime", next.Format(time.RFC3339))
+	}
+	return next
+}
+
+// updateRetryStatus records a transient failure of a scheduled run that stays active to be tried
+// again; only the final outcome of the run is added to the history
+func (r *WorkPackageReconciler) updateRetryStatus(ctx context.Context, wp *v1alpha1.WorkPackages, run ticketRun, record *v1alpha1.WorkPackageRunRecord, retries int, log logr.Logger) {
+	message := fmt.Sprintf("Ticket creation failed, retry %d of %d: %s", retries, RunMaxRetries, record.Message)
+	active := wp.Status.ActiveRun.DeepCopy()
+	active.Retries = int32(retries)
+	update := WorkPackageStatusUpdate{
+		ActiveRun:  active,
+		Status:     StatusFailed,
+		Message:    message,
+		Conditions: failureConditions(wp, record, message),
+	}
+
+	r.Recorder.Eventf(wp, corev1.EventTypeWarning, v1alpha1.ReasonTicketCreationFailed, "%s (run key %s)", message, run.RunKey)
+
+	if err := applyStatusUpdate(ctx, r, wp, update, log); err != nil {
+		log.Error(err, "❌ Failed to update failed status")
+	}
+	statusLog(log, "🔁", "Ticket creation will be retried", "category", record.ErrorCategory, "retry", retries)
 }
 
-// loadConfig loads the server configuration and API key
-func (r *WorkPackageReconciler) loadConfig(ctx context.Context, wp *v1alpha1.WorkPackages, log logr.Logger) (*v1alpha1.ServerConfig, string, error) {
-	config, err := configloader.LoadServerConfig(ctx, r.Client, wp.Spec.ServerConfigRef.Name, wp.Namespace)
+// loadConfig loads the server configuration and API key and returns a client for the server
+func (r *WorkPackageReconciler) loadConfig(ctx context.Context, wp *v1alpha1.WorkPackages, log logr.Logger) (*v1alpha1.ServerConfig, *openproject.Client, error) {
+	config, err := configloader.LoadServerConfig(ctx, r.Client, wp.Spec.ServerConfigRef, wp.Namespace)
 	if err != nil {
 		log.Error(err, "❌ Could not load ServerConfig", "serverconfig", wp.Spec.ServerConfigRef.Name)
-		return nil, "", err
+		reason := v1alpha1.ReasonServerConfigNotFound
+		if errors.Is(err, configloader.ErrNotGranted) {
+			reason = v1alpha1.ReasonServerConfigNotGranted
+		}
+		r.markConfigUnavailable(ctx, wp, v1alpha1.ConditionReady, reason, err, log)
+		return nil, nil, err
 	}
 	statusLog(log, "🛠", "ServerConfig loaded", "serverconfig", wp.Spec.ServerConfigRef.Name)
 
-	apiKey, err := configloader.LoadAPIKey(ctx, r.Client, config)
+	op, err := newOpenProjectClient(ctx, r.Client, config)
 	if err != nil {
-		log.Error(err, "❌ Failed to load OpenProject API key", "serverconfig", config.Name)
-		return nil, "", err
+		log.Error(err, "❌ Failed to load OpenProject credentials or TLS settings", "serverconfig", config.Name)
+		r.markConfigUnavailable(ctx, wp, v1alpha1.ConditionCredentialsValid, v1alpha1.ReasonSecretNotFound, err, log)
+		return nil, nil, err
 	}
 
-	return config, strings.TrimSpace(apiKey), nil
+	return config, op, nil
+}
+
+// markConfigUnavailable records that the server configuration or its credentials could not be loaded
+func (r *WorkPackageReconciler) markConfigUnavailable(ctx context.Context, wp *v1alpha1.WorkPackages, conditionType, reason string, cause error, log logr.Logger) {
+	conditions := []metav1.Condition{
+		newCondition(v1alpha1.ConditionReady, metav1.ConditionFalse, reason, cause.Error()),
+	}
+	if conditionType != v1alpha1.ConditionReady {
+		conditions = append(conditions, newCondition(conditionType, metav1.ConditionFalse, reason, cause.Error()))
+	}
+	r.Recorder.Event(wp, corev1.EventTypeWarning, reason, cause.Error())
+	if err := applyStatusUpdate(ctx, r, wp, WorkPackageStatusUpdate{Conditions: conditions}, log); err != nil {
+		log.Error(err, "❌ Failed to patch status")
+	}
 }
 
 // +kubebuilder:rbac:groups=openproject.org,resources=workpackages,verbs=get;list;watch;create;update;patch;delete
@@ -586,6 +958,26 @@ func (r *WorkPackageReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	log := getScopedLogger(ctx, &wp)
 
+	// Validate the schedule's time zone before evaluating it
+	loc, err := scheduleLocation(&wp)
+	if err != nil {
+		log.Error(err, "❌ Invalid time zone", "timeZone", wp.Spec.TimeZone)
+		r.Recorder.Event(&wp, corev1.EventTypeWarning, v1alpha1.ReasonInvalidSpec, err.Error())
+		update := WorkPackageStatusUpdate{
+			Status:  StatusFailed,
+			Message: err.Error(),
+			Conditions: []metav1.Condition{
+				newCondition(v1alpha1.ConditionReady, metav1.ConditionFalse, v1alpha1.ReasonInvalidSpec, err.Error()),
+				newCondition(v1alpha1.ConditionDegraded, metav1.ConditionTrue, v1alpha1.ReasonInvalidSpec, err.Error()),
+			},
+		}
+		if err := applyStatusUpdate(ctx, r, &wp, update, log); err != nil {
+			log.Error(err, "❌ Failed to patch status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
 	// Initialize if needed
 	if wp.Status.LastRunTime == nil {
 		if wp.Status.Status != StatusScheduled || wp.Status.NextRunTime == nil {
@@ -593,33 +985,129 @@ func (r *WorkPackageReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		}
 	}
 
-	// Check if it's time to run
-	if !shouldRunNow(wp.Spec.Schedule, wp.Status.LastRunTime, wp.CreationTimestamp) {
-		statusLog(log, "⏳", "Not time to run yet based on schedule", "schedule", wp.Spec.Schedule)
-		return ctrl.Result{RequeueAfter: DefaultRequeueTime}, nil
+	// An unhandled run-now annotation triggers a run even while suspended
+	if token := wp.Annotations[v1alpha1.RunNowAnnotation]; token != "" && token != wp.Status.LastHandledRunNow {
+		statusLog(log, "▶", "Run requested by annotation", "runNow", token)
+		config, op, err := r.loadConfig(ctx, &wp, log)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		targets, err := r.resolveTargets(ctx, &wp, config, op, log)
+		if err != nil {
+			return ctrl.Result{RequeueAfter: DefaultRequeueTime}, nil
+		}
+		return r.handleCreateTicket(ctx, &wp, op, targets, newManualRun(&wp, token, loc), log)
+	}
+
+	if wp.Spec.Suspend {
+		if wp.Status.Status != StatusSuspended || wp.Status.ObservedGeneration != wp.Generation {
+			update := WorkPackageStatusUpdate{
+				Status:  StatusSuspended,
+				Message: "Scheduled runs are suspended",
+				Conditions: []metav1.Condition{
+					newCondition(v1alpha1.ConditionReady, metav1.ConditionTrue, v1alpha1.ReasonSuspended, "Scheduled runs are suspended"),
+				},
+			}
+			if err := applyStatusUpdate(ctx, r, &wp, update, log); err != nil {
+				log.Error(err, "❌ Failed to patch status")
+				return ctrl.Result{}, err
+			}
+		}
+		statusLog(log, "⏸", "Suspended; skipping schedule evaluation")
+		return ctrl.Result{}, nil
+	}
+
+	// Decide which schedule slot, if any, runs now
+	plan, err := planRun(&wp, loc, time.Now())
+	if err != nil {
+		log.Error(err, "❌ Failed to parse cron schedule")
+		return ctrl.Result{}, err
+	}
+
+	if plan.Scheduled == nil {
+		if plan.Skipped > 0 {
+			return r.recordSkippedRuns(ctx, &wp, plan, log)
+		}
+		if wp.Status.ObservedGeneration != wp.Generation {
+			update := WorkPackageStatusUpdate{
+				Conditions: []metav1.Condition{
+					newCondition(v1alpha1.ConditionReady, metav1.ConditionTrue, v1alpha1.ReasonScheduled, "Waiting for the next scheduled run"),
+				},
+			}
+			if err := applyStatusUpdate(ctx, r, &wp, update, log); err != nil {
+				log.Error(err, "❌ Failed to patch status")
+				return ctrl.Result{}, err
+			}
+		}
+		statusLog(log, "⏳", "Not time to run yet based on schedule", "schedule", wp.Spec.Schedule, "timeZone", loc.String())
+		return ctrl.Result{RequeueAfter: requeueUntil(plan.Next)}, nil
+	}
+
+	if plan.Skipped > 0 {
+		policy := wp.Spec.MissedRunPolicy
+		if policy == "" {
+			policy = MissedRunOnce
+		}
+		r.Recorder.Eventf(&wp, corev1.EventTypeNormal, v1alpha1.ReasonRunsSkipped,
+			"Skipped %d missed run(s) under the %s policy; running the slot of %s", plan.Skipped, policy, plan.Scheduled.Format(time.RFC3339))
+		statusLog(log, "⏭", "Skipped missed runs", "count", plan.Skipped, "policy", policy)
+	}
+	if plan.Resumed {
+		statusLog(log, "🔁", "Resuming active run", "runKey", wp.Status.ActiveRun.RunKey)
 	}
 
 	// Load configuration
-	config, apiKey, err := r.loadConfig(ctx, &wp, log)
+	config, op, err := r.loadConfig(ctx, &wp, log)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
+	// Resolve project, type and people on this server
+	targets, err := r.resolveTargets(ctx, &wp, config, op, log)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: DefaultRequeueTime}, nil
+	}
+
 	// Create the ticket
-	return r.handleCreateTicket(ctx, &wp, config, apiKey, log)
+	run := ticketRun{Scheduled: *plan.Scheduled, RunKey: buildRunKey(&wp, *plan.Scheduled)}
+	return r.handleCreateTicket(ctx, &wp, op, targets, run, log)
+}
+
+// recordSkippedRuns marks slots passed over by the scheduling policies as handled
+func (r *WorkPackageReconciler) recordSkippedRuns(ctx context.Context, wp *v1alpha1.WorkPackages, plan runPlan, log logr.Logger) (ctrl.Result, error) {
+	update := WorkPackageStatusUpdate{
+		NextRunTime:      &metav1.Time{Time: plan.Next},
+		LastScheduleTime: &metav1.Time{Time: *plan.LastSkipped},
+		ClearActiveRun:   true,
+		Status:           StatusScheduled,
+		Message:          fmt.Sprintf("Skipped %d missed run(s); next run scheduled for %s", plan.Skipped, plan.Next.Format(time.RFC3339)),
+		Conditions: []metav1.Condition{
+			newCondition(v1alpha1.ConditionReady, metav1.ConditionTrue, v1alpha1.ReasonScheduled, "Missed runs skipped"),
+		},
+	}
+	if err := applyStatusUpdate(ctx, r, wp, update, log); err != nil {
+		log.Error(err, "❌ Failed to record skipped runs")
+		return ctrl.Result{}, err
+	}
+
+	r.Recorder.Event(wp, corev1.EventTypeNormal, v1alpha1.ReasonRunsSkipped, update.Message)
+	statusLog(log, "⏭", "Skipped missed runs", "count", plan.Skipped, "nextRunTime", plan.Next.Format(time.RFC3339))
+	return ctrl.Result{RequeueAfter: requeueUntil(plan.Next)}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *WorkPackageReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	if err := mgr.GetFieldIndexer().IndexField(context.TODO(), &v1alpha1.CloudInventoryReport{},
-		IndexFieldSourceRefName, func(obj client.Object) []string {
-			report := obj.(*v1alpha1.CloudInventoryReport)
-			return []string{report.Spec.SourceRef.Name}
-		}); err != nil {
-		return err
-	}
+	// Status updates of a config, such as periodic validation, need no reconcile of its users
+	specChanged := builder.WithPredicates(predicate.GenerationChangedPredicate{})
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.WorkPackages{}).
+		Watches(&v1alpha1.ServerConfig{},
+			handler.EnqueueRequestsFromMapFunc(r.workPackagesForConfig(v1alpha1.ServerConfigKind)), specChanged).
+		Watches(&v1alpha1.ClusterServerConfig{},
+			handler.EnqueueRequestsFromMapFunc(r.workPackagesForConfig(v1alpha1.ClusterServerConfigKind)), specChanged).
+		Watches(&v1alpha1.ServerConfigGrant{}, handler.EnqueueRequestsFromMapFunc(r.workPackagesForGrant)).
+		WatchesMetadata(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.workPackagesForCredentials(IndexFieldSecretNames))).
+		WatchesMetadata(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.workPackagesForCredentials(IndexFieldConfigMapNames))).
 		Complete(r)
 }
 
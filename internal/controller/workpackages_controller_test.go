@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+)
+
+func TestScheduleLocation(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeZone string
+		want     string
+		wantErr  bool
+	}{
+		{name: "operator local time", timeZone: "", want: time.Local.String()},
+		{name: "UTC", timeZone: "UTC", want: "UTC"},
+		{name: "IANA name", timeZone: "Europe/Berlin", want: "Europe/Berlin"},
+		{name: "unknown zone", timeZone: "Mars/Olympus_Mons", wantErr: true},
+		{name: "abbreviation", timeZone: "CEST", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wp := &v1alpha1.WorkPackages{Spec: v1alpha1.WorkPackagesSpec{TimeZone: tt.timeZone}}
+			loc, err := scheduleLocation(wp)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("scheduleLocation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && loc.String() != tt.want {
+				t.Errorf("scheduleLocation() = %q, want %q", loc, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeZoneName(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	local, _ := time.Now().Zone()
+
+	if got := timeZoneName(berlin); got != "Europe/Berlin" {
+		t.Errorf("timeZoneName(Europe/Berlin) = %q", got)
+	}
+	if got := timeZoneName(time.Local); got != local {
+		t.Errorf("timeZoneName(Local) = %q, want %q", got, local)
+	}
+}
+
+func TestNextScheduledRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule string
+		timeZone string
+		from     string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "UTC",
+			schedule: "0 9 1 * *",
+			timeZone: "UTC",
+			from:     "2026-01-15T00:00:00Z",
+			want:     "2026-02-01T09:00:00Z",
+		},
+		{
+			name:     "standard time",
+			schedule: "0 9 1 * *",
+			timeZone: "Europe/Berlin",
+			from:     "2026-01-15T00:00:00Z",
+			want:     "2026-02-01T08:00:00Z",
+		},
+		{
+			name:     "summer time",
+			schedule: "0 9 1 * *",
+			timeZone: "Europe/Berlin",
+			from:     "2026-03-15T00:00:00Z",
+			want:     "2026-04-01T07:00:00Z",
+		},
+		{
+			name:     "across a daylight saving change",
+			schedule: "0 9 * * *",
+			timeZone: "America/New_York",
+			from:     "2026-03-07T15:00:00Z",
+			want:     "2026-03-08T13:00:00Z",
+		},
+		{
+			name:     "local day differs from UTC day",
+			schedule: "0 8 * * 1",
+			timeZone: "Asia/Tokyo",
+			from:     "2026-10-11T12:00:00Z",
+			want:     "2026-10-11T23:00:00Z",
+		},
+		{
+			name:     "invalid time zone",
+			schedule: "0 9 * * *",
+			timeZone: "Nowhere/Special",
+			from:     "2026-01-15T00:00:00Z",
+			wantErr:  true,
+		},
+		{
+			name:     "invalid schedule",
+			schedule: "every day",
+			timeZone: "UTC",
+			from:     "2026-01-15T00:00:00Z",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, err := time.Parse(time.RFC3339, tt.from)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wp := &v1alpha1.WorkPackages{Spec: v1alpha1.WorkPackagesSpec{Schedule: tt.schedule, TimeZone: tt.timeZone}}
+
+			got, err := nextScheduledRun(wp, from)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("nextScheduledRun() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if want, _ := time.Parse(time.RFC3339, tt.want); !got.Equal(want) {
+				t.Errorf("nextScheduledRun() = %s, want %s", got.UTC().Format(time.RFC3339), tt.want)
+			}
+		})
+	}
+}
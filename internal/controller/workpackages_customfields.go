@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	"github.com/shrapk2/openproject-operator/internal/openproject"
+)
+
+// resolveCustomFields maps spec.customFields onto the attribute keys and values of the
+// project's work package schema. Plain values go to fields, references to links.
+func resolveCustomFields(ctx context.Context, op *openproject.Client, config *v1alpha1.ServerConfig, wp *v1alpha1.WorkPackages, targets *ticketTargets) error {
+	if len(wp.Spec.CustomFields) == 0 {
+		return nil
+	}
+
+	schema, err := op.GetWorkPackageSchema(ctx, wp.Status.ResolvedProjectID, wp.Status.ResolvedTypeID)
+	if err != nil {
+		return fmt.Errorf("failed to load work package schema: %w", err)
+	}
+
+	targets.CustomFields = map[string]interface{}{}
+	targets.CustomFieldLinks = map[string]interface{}{}
+
+	// Sorted so the first reported error is stable
+	names := make([]string, 0, len(wp.Spec.CustomFields))
+	for name := range wp.Spec.CustomFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var problems []string
+	for _, name := range names {
+		value := wp.Spec.CustomFields[name]
+		key, field, ok := schema.CustomField(name)
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%q is not a custom field of this project and type", name))
+			continue
+		}
+		if err := setCustomField(ctx, op, config, wp, targets, key, field, value); err != nil {
+			problems = append(problems, fmt.Sprintf("%q: %v", name, err))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid custom fields: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// setCustomField converts one value according to the custom field's schema type
+func setCustomField(ctx context.Context, op *openproject.Client, config *v1alpha1.ServerConfig, wp *v1alpha1.WorkPackages, targets *ticketTargets, key string, field openproject.SchemaField, value string) error {
+	if !field.Writable {
+		return fmt.Errorf("field is not writable")
+	}
+
+	switch field.Type {
+	case "String", "Link":
+		targets.CustomFields[key] = value
+	case "Formattable":
+		targets.CustomFields[key] = openproject.Markdown(value)
+	case "Integer":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+		targets.CustomFields[key] = n
+	case "Float":
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+		targets.CustomFields[key] = f
+	case "Boolean":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", value)
+		}
+		targets.CustomFields[key] = b
+	case "Date":
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return fmt.Errorf("%q is not a date (YYYY-MM-DD)", value)
+		}
+		targets.CustomFields[key] = value
+	case "CustomOption":
+		link, err := customOption(field, value)
+		if err != nil {
+			return err
+		}
+		targets.CustomFieldLinks[key] = link
+	case "[]CustomOption":
+		var links []*openproject.Link
+		for _, label := range splitList(value) {
+			link, err := customOption(field, label)
+			if err != nil {
+				return err
+			}
+			links = append(links, link)
+		}
+		targets.CustomFieldLinks[key] = links
+	case "User":
+		link, err := resolvePrincipal(ctx, op, config, value, true)
+		if err != nil {
+			return err
+		}
+		targets.CustomFieldLinks[key] = link
+	case "[]User":
+		var links []*openproject.Link
+		for _, ref := range splitList(value) {
+			link, err := resolvePrincipal(ctx, op, config, ref, true)
+			if err != nil {
+				return err
+			}
+			links = append(links, link)
+		}
+		targets.CustomFieldLinks[key] = links
+	case "Version":
+		link, err := projectVersion(ctx, op, wp.Status.ResolvedProjectID, value)
+		if err != nil {
+			return err
+		}
+		targets.CustomFieldLinks[key] = link
+	default:
+		return fmt.Errorf("custom fields of type %s are not supported", field.Type)
+	}
+	return nil
+}
+
+// customOption finds a list option by its label
+func customOption(field openproject.SchemaField, label string) (*openproject.Link, error) {
+	labels := make([]string, 0, len(field.AllowedValues))
+	for _, option := range field.AllowedValues {
+		if strings.EqualFold(option.Title, label) {
+			return &openproject.Link{Href: option.Href}, nil
+		}
+		labels = append(labels, option.Title)
+	}
+	return nil, fmt.Errorf("%q is not one of %s", label, strings.Join(labels, ", "))
+}
+
+// projectVersion finds a version of the project by name
+func projectVersion(ctx context.Context, op *openproject.Client, projectID int, name string) (*openproject.Link, error) {
+	versions, err := op.ListProjectVersions(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list versions: %w", err)
+	}
+	for _, v := range versions {
+		if strings.EqualFold(v.Name, name) {
+			return openproject.LinkTo("versions", v.ID), nil
+		}
+	}
+	return nil, fmt.Errorf("version %q not found", name)
+}
+
+// splitList splits a comma separated value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	"github.com/shrapk2/openproject-operator/internal/openproject"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetCustomField(t *testing.T) {
+	options := []openproject.Link{
+		{Href: "/api/v3/custom_options/1", Title: "Low"},
+		{Href: "/api/v3/custom_options/2", Title: "High"},
+	}
+
+	tests := []struct {
+		name      string
+		field     openproject.SchemaField
+		value     string
+		want      interface{}
+		wantLink  interface{}
+		wantError string
+	}{
+		{name: "string", field: openproject.SchemaField{Type: "String", Writable: true}, value: "prod", want: "prod"},
+		{name: "formattable", field: openproject.SchemaField{Type: "Formattable", Writable: true}, value: "**x**", want: openproject.Markdown("**x**")},
+		{name: "integer", field: openproject.SchemaField{Type: "Integer", Writable: true}, value: "42", want: 42},
+		{name: "invalid integer", field: openproject.SchemaField{Type: "Integer", Writable: true}, value: "4.2", wantError: "not an integer"},
+		{name: "float", field: openproject.SchemaField{Type: "Float", Writable: true}, value: "4.2", want: 4.2},
+		{name: "invalid float", field: openproject.SchemaField{Type: "Float", Writable: true}, value: "many", wantError: "not a number"},
+		{name: "boolean", field: openproject.SchemaField{Type: "Boolean", Writable: true}, value: "true", want: true},
+		{name: "invalid boolean", field: openproject.SchemaField{Type: "Boolean", Writable: true}, value: "yes", wantError: "not a boolean"},
+		{name: "date", field: openproject.SchemaField{Type: "Date", Writable: true}, value: "2026-10-17", want: "2026-10-17"},
+		{name: "invalid date", field: openproject.SchemaField{Type: "Date", Writable: true}, value: "17.10.2026", wantError: "not a date"},
+		{
+			name:     "option by label",
+			field:    openproject.SchemaField{Type: "CustomOption", Writable: true, AllowedValues: options},
+			value:    "high",
+			wantLink: &openproject.Link{Href: "/api/v3/custom_options/2"},
+		},
+		{
+			name:      "unknown option",
+			field:     openproject.SchemaField{Type: "CustomOption", Writable: true, AllowedValues: options},
+			value:     "Urgent",
+			wantError: `"Urgent" is not one of Low, High`,
+		},
+		{
+			name:     "multiple options",
+			field:    openproject.SchemaField{Type: "[]CustomOption", Writable: true, AllowedValues: options},
+			value:    "Low, High,",
+			wantLink: []*openproject.Link{{Href: "/api/v3/custom_options/1"}, {Href: "/api/v3/custom_options/2"}},
+		},
+		{name: "read only", field: openproject.SchemaField{Type: "String"}, value: "prod", wantError: "not writable"},
+		{name: "unsupported type", field: openproject.SchemaField{Type: "Money", Writable: true}, value: "1", wantError: "not supported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targets := &ticketTargets{CustomFields: map[string]interface{}{}, CustomFieldLinks: map[string]interface{}{}}
+
+			err := setCustomField(context.Background(), nil, nil, &v1alpha1.WorkPackages{}, targets, "customField3", tt.field, tt.value)
+			if tt.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+					t.Fatalf("setCustomField() error = %v, want %q", err, tt.wantError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("setCustomField() error = %v", err)
+			}
+			if got := targets.CustomFields["customField3"]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("field = %#v, want %#v", got, tt.want)
+			}
+			if got := targets.CustomFieldLinks["customField3"]; !reflect.DeepEqual(got, tt.wantLink) {
+				t.Errorf("link = %#v, want %#v", got, tt.wantLink)
+			}
+		})
+	}
+}
+
+func TestResolveCustomFields(t *testing.T) {
+	schema := `{"_type":"Schema",` +
+		`"subject":{"type":"String","name":"Subject","writable":true},` +
+		`"customField3":{"type":"Integer","name":"Ticket Budget","writable":true},` +
+		`"customField4":{"type":"Version","name":"Release","writable":true},` +
+		`"customField5":{"type":"CustomOption","name":"Severity","writable":true,` +
+		`"_links":{"allowedValues":[{"href":"/api/v3/custom_options/1","title":"Low"}]}}}`
+
+	tests := []struct {
+		name       string
+		fields     map[string]string
+		wantFields map[string]interface{}
+		wantLinks  map[string]interface{}
+		wantError  string
+	}{
+		{
+			name:       "fields and links",
+			fields:     map[string]string{"ticket budget": "12", "Severity": "low", "Release": "2026.10"},
+			wantFields: map[string]interface{}{"customField3": 12},
+			wantLinks: map[string]interface{}{
+				"customField4": openproject.LinkTo("versions", 8),
+				"customField5": &openproject.Link{Href: "/api/v3/custom_options/1"},
+			},
+		},
+		{
+			name:      "unknown field",
+			fields:    map[string]string{"Subject": "x", "Team": "ops"},
+			wantError: `invalid custom fields: "Subject" is not a custom field of this project and type; "Team" is not a custom field`,
+		},
+		{
+			name:      "unknown version",
+			fields:    map[string]string{"Release": "2030.1"},
+			wantError: `"Release": version "2030.1" not found`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeOpenProject(t, map[string]string{
+				"GET /work_packages/schemas/7-1": schema,
+				"GET /projects/7/versions":       `{"total":1,"count":1,"_embedded":{"elements":[{"id":8,"name":"2026.10"}]}}`,
+			})
+			config := &v1alpha1.ServerConfig{ObjectMeta: metav1.ObjectMeta{Name: "customfields", Namespace: "test"}}
+			wp := &v1alpha1.WorkPackages{
+				Spec:   v1alpha1.WorkPackagesSpec{CustomFields: tt.fields},
+				Status: v1alpha1.WorkPackagesStatus{ResolvedProjectID: 7, ResolvedTypeID: 1},
+			}
+
+			targets := &ticketTargets{}
+			err := resolveCustomFields(context.Background(), f.client(), config, wp, targets)
+			if tt.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+					t.Fatalf("resolveCustomFields() error = %v, want %q", err, tt.wantError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveCustomFields() error = %v", err)
+			}
+			if !reflect.DeepEqual(targets.CustomFields, tt.wantFields) {
+				t.Errorf("fields = %#v, want %#v", targets.CustomFields, tt.wantFields)
+			}
+			if !reflect.DeepEqual(targets.CustomFieldLinks, tt.wantLinks) {
+				t.Errorf("links = %#v, want %#v", targets.CustomFieldLinks, tt.wantLinks)
+			}
+		})
+	}
+}
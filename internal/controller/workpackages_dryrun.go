@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	"github.com/shrapk2/openproject-operator/internal/openproject"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+
+// Keys of the dry run ConfigMap
+const (
+	DryRunPayloadKey     = "payload.json"
+	DryRunDescriptionKey = "description.md"
+)
+
+// dryRunConfigMapName is the ConfigMap a WorkPackages renders its dry runs into
+func dryRunConfigMapName(wp *v1alpha1.WorkPackages) string {
+	return wp.Name + "-dry-run"
+}
+
+// handleDryRun renders a run as usual but writes the request to a ConfigMap instead of sending
+// it, then checks a create payload with the work package form. Nothing is created on the server.
+func (r *WorkPackageReconciler) handleDryRun(ctx context.Context, wp *v1alpha1.WorkPackages, op *openproject.Client, targets *ticketTargets, run ticketRun, record v1alpha1.WorkPackageRunRecord, log logr.Logger) (ctrl.Result, error) {
+	statusLog(log, "🧪", "Rendering dry run", "subject", wp.Spec.Subject, "runKey", run.RunKey)
+
+	payload, report, err := r.buildTicketPayload(ctx, wp, targets, run, log)
+	if report != nil {
+		record.InventoryReport = report.Name
+	}
+	if err != nil {
+		log.Error(err, "❌ Failed to build ticket payload")
+		return r.handleRunFailure(ctx, wp, run, record, err, log)
+	}
+	description := payload["description"].(map[string]string)["raw"]
+
+	// Comment mode would post only the description
+	var body interface{} = payload
+	if wp.Spec.Mode == ModeComment {
+		body = openproject.Activity{Comment: &openproject.Formattable{Raw: description}}
+	}
+	if err := r.writeDryRunConfigMap(ctx, wp, body, description); err != nil {
+		log.Error(err, "❌ Failed to write dry run ConfigMap", "configMap", dryRunConfigMapName(wp))
+		return ctrl.Result{}, err
+	}
+
+	result := &v1alpha1.DryRunResult{
+		ConfigMapName: dryRunConfigMapName(wp),
+		RenderTime:    metav1.Now(),
+	}
+	condition := validateDryRun(ctx, wp, op, payload, result)
+	statusLog(log, "🧪", "Dry run rendered", "configMap", result.ConfigMapName, "validation", condition.Reason)
+
+	completeRunRecord(&record, RunOutcomeDryRun, "Dry run rendered into ConfigMap "+result.ConfigMapName+"; "+result.Message)
+	return r.recordDryRun(ctx, wp, run, record, result, condition, log)
+}
+
+// writeDryRunConfigMap stores the request body and rendered markdown in a ConfigMap owned by wp
+func (r *WorkPackageReconciler) writeDryRunConfigMap(ctx context.Context, wp *v1alpha1.WorkPackages, body interface{}, description string) error {
+	data, err := json.MarshalIndent(body, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: dryRunConfigMapName(wp), Namespace: wp.Namespace}}
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		cm.Data = map[string]string{
+			DryRunPayloadKey:     string(data),
+			DryRunDescriptionKey: description,
+		}
+		return controllerutil.SetControllerReference(wp, cm, r.Scheme)
+	})
+	return err
+}
+
+// validateDryRun checks a create payload with the work package form, filling in the result, and
+// returns the PayloadValid condition. Comments have no form and are not checked.
+func validateDryRun(ctx context.Context, wp *v1alpha1.WorkPackages, op *openproject.Client, payload map[string]interface{}, result *v1alpha1.DryRunResult) metav1.Condition {
+	if wp.Spec.Mode == ModeComment {
+		result.Message = "comments are not validated"
+		return newCondition(v1alpha1.ConditionPayloadValid, metav1.ConditionUnknown, v1alpha1.ReasonFormUnavailable, result.Message)
+	}
+
+	form, err := op.ValidateWorkPackage(ctx, wp.Status.ResolvedProjectID, payload)
+	if err != nil {
+		result.Message = "payload could not be validated: " + err.Error()
+		return newCondition(v1alpha1.ConditionPayloadValid, metav1.ConditionUnknown, v1alpha1.ReasonFormUnavailable, result.Message)
+	}
+
+	valid := len(form.Embedded.ValidationErrors) == 0
+	result.Valid = &valid
+	if valid {
+		result.Message = "payload accepted by OpenProject"
+		return newCondition(v1alpha1.ConditionPayloadValid, metav1.ConditionTrue, v1alpha1.ReasonPayloadAccepted, result.Message)
+	}
+
+	for attribute, formErr := range form.Embedded.ValidationErrors {
+		result.ValidationErrors = append(result.ValidationErrors, attribute+": "+formErr.Message)
+	}
+	sort.Strings(result.ValidationErrors)
+	result.Message = fmt.Sprintf("payload rejected by OpenProject with %d validation error(s)", len(result.ValidationErrors))
+	return newCondition(v1alpha1.ConditionPayloadValid, metav1.ConditionFalse, v1alpha1.ReasonPayloadRejected, result.Message)
+}
+
+// recordDryRun marks a dry run as done like a created ticket, without a ticket ID
+func (r *WorkPackageReconciler) recordDryRun(ctx context.Context, wp *v1alpha1.WorkPackages, run ticketRun, record v1alpha1.WorkPackageRunRecord, result *v1alpha1.DryRunResult, payloadCondition metav1.Condition, log logr.Logger) (ctrl.Result, error) {
+	now := time.Now()
+	update := WorkPackageStatusUpdate{
+		LastRunTime:  &metav1.Time{Time: now},
+		HistoryEntry: &record,
+		DryRun:       result,
+		Conditions: append([]metav1.Condition{
+			newCondition(v1alpha1.ConditionReady, metav1.ConditionTrue, v1alpha1.ReasonDryRun, record.Message),
+			newCondition(v1alpha1.ConditionLastRunSucceeded, metav1.ConditionTrue, v1alpha1.ReasonDryRun, record.Message),
+			newCondition(v1alpha1.ConditionDegraded, metav1.ConditionFalse, v1alpha1.ReasonAsExpected, ""),
+			payloadCondition,
+		}, inventoryCondition(wp, &record)...),
+		Status:  StatusDryRun,
+		Message: record.Message,
+	}
+	next := finishRun(wp, run, &update, now)
+
+	eventType := corev1.EventTypeNormal
+	if payloadCondition.Status == metav1.ConditionFalse {
+		eventType = corev1.EventTypeWarning
+	}
+	r.Recorder.Eventf(wp, eventType, v1alpha1.ReasonDryRun, "%s (run key %s)", record.Message, run.RunKey)
+
+	if err := applyStatusUpdate(ctx, r, wp, update, log); err != nil {
+		log.Error(err, "❌ Failed to patch status")
+	}
+	return ctrl.Result{RequeueAfter: requeueUntil(next)}, nil
+}
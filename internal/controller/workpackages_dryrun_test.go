@@ -0,0 +1,164 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestValidateDryRun(t *testing.T) {
+	payload := map[string]interface{}{"subject": "October Patch review"}
+
+	tests := []struct {
+		name       string
+		mode       string
+		responses  map[string]string
+		wantStatus metav1.ConditionStatus
+		wantReason string
+		wantValid  *bool
+		wantErrors []string
+	}{
+		{
+			name:       "payload accepted",
+			responses:  map[string]string{"POST /projects/7/work_packages/form": `{"_embedded":{"validationErrors":{}}}`},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: v1alpha1.ReasonPayloadAccepted,
+			wantValid:  ptrTo(true),
+		},
+		{
+			name: "payload rejected",
+			responses: map[string]string{"POST /projects/7/work_packages/form": `{"_embedded":{"validationErrors":{` +
+				`"subject":{"message":"Subject can't be blank."},"assignee":{"message":"Assignee is invalid."}}}}`},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: v1alpha1.ReasonPayloadRejected,
+			wantValid:  ptrTo(false),
+			wantErrors: []string{"assignee: Assignee is invalid.", "subject: Subject can't be blank."},
+		},
+		{
+			name:       "form unavailable",
+			wantStatus: metav1.ConditionUnknown,
+			wantReason: v1alpha1.ReasonFormUnavailable,
+		},
+		{
+			name:       "comments are not validated",
+			mode:       ModeComment,
+			wantStatus: metav1.ConditionUnknown,
+			wantReason: v1alpha1.ReasonFormUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeOpenProject(t, tt.responses)
+			wp := &v1alpha1.WorkPackages{
+				Spec:   v1alpha1.WorkPackagesSpec{Mode: tt.mode},
+				Status: v1alpha1.WorkPackagesStatus{ResolvedProjectID: 7},
+			}
+			var result v1alpha1.DryRunResult
+
+			condition := validateDryRun(context.Background(), wp, f.client(), payload, &result)
+			if condition.Status != tt.wantStatus || condition.Reason != tt.wantReason {
+				t.Errorf("validateDryRun() condition = %s/%s, want %s/%s", condition.Status, condition.Reason, tt.wantStatus, tt.wantReason)
+			}
+			if (result.Valid == nil) != (tt.wantValid == nil) || (result.Valid != nil && *result.Valid != *tt.wantValid) {
+				t.Errorf("validateDryRun() valid = %v, want %v", result.Valid, tt.wantValid)
+			}
+			if !slices.Equal(result.ValidationErrors, tt.wantErrors) {
+				t.Errorf("validateDryRun() errors = %v, want %v", result.ValidationErrors, tt.wantErrors)
+			}
+			if result.Message == "" {
+				t.Error("validateDryRun() left the message empty")
+			}
+			if writes := f.writes(); tt.mode == ModeComment && len(writes) > 0 {
+				t.Errorf("validateDryRun() sent %v for a comment", writes)
+			}
+		})
+	}
+}
+
+func TestHandleDryRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        string
+		wantPayload string
+	}{
+		{name: "create mode stores the work package payload", wantPayload: `"subject": "October Patch review"`},
+		{name: "comment mode stores the comment", mode: ModeComment, wantPayload: `"comment": {`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduled := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+			wp := &v1alpha1.WorkPackages{
+				ObjectMeta: metav1.ObjectMeta{Name: "patch-review", Namespace: "team-a", UID: "8f0c"},
+				Spec: v1alpha1.WorkPackagesSpec{
+					Schedule:    "0 9 1 * *",
+					TimeZone:    "UTC",
+					Subject:     "Patch review",
+					Description: "Review the patch level",
+					Mode:        tt.mode,
+					DryRun:      true,
+				},
+				Status: v1alpha1.WorkPackagesStatus{ResolvedProjectID: 7, ResolvedTypeID: 1},
+			}
+			r := newTestWorkPackageReconciler(t, wp)
+			f := newFakeOpenProject(t, map[string]string{
+				"POST /projects/7/work_packages/form": `{"_embedded":{"validationErrors":{}}}`,
+			})
+			run := ticketRun{Scheduled: scheduled, RunKey: buildRunKey(wp, scheduled)}
+
+			if _, err := r.handleDryRun(context.Background(), wp, f.client(), &ticketTargets{}, run, v1alpha1.WorkPackageRunRecord{}, logr.Discard()); err != nil {
+				t.Fatalf("handleDryRun() error = %v", err)
+			}
+
+			// Nothing but the form may be sent to OpenProject
+			for _, req := range f.writes() {
+				if req != "POST /projects/7/work_packages/form" {
+					t.Errorf("handleDryRun() sent %s", req)
+				}
+			}
+
+			var cm corev1.ConfigMap
+			if err := r.Get(context.Background(), client.ObjectKey{Name: "patch-review-dry-run", Namespace: "team-a"}, &cm); err != nil {
+				t.Fatalf("dry run ConfigMap: %v", err)
+			}
+			if !json.Valid([]byte(cm.Data[DryRunPayloadKey])) || !strings.Contains(cm.Data[DryRunPayloadKey], tt.wantPayload) {
+				t.Errorf("ConfigMap %s = %s, want it to contain %s", DryRunPayloadKey, cm.Data[DryRunPayloadKey], tt.wantPayload)
+			}
+			description := cm.Data[DryRunDescriptionKey]
+			if !strings.HasPrefix(description, "Review the patch level") || !strings.Contains(description, run.RunKey) {
+				t.Errorf("ConfigMap %s = %q, want the rendered description with the run key", DryRunDescriptionKey, description)
+			}
+			if owner := metav1.GetControllerOf(&cm); owner == nil || owner.UID != wp.UID {
+				t.Errorf("ConfigMap owner = %v, want the WorkPackages", owner)
+			}
+
+			var got v1alpha1.WorkPackages
+			if err := r.Get(context.Background(), client.ObjectKeyFromObject(wp), &got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Status.DryRun == nil || got.Status.DryRun.ConfigMapName != "patch-review-dry-run" {
+				t.Errorf("status.dryRun = %+v, want the ConfigMap name", got.Status.DryRun)
+			}
+			if got.Status.TicketID != "" {
+				t.Errorf("status.ticketID = %q, want none for a dry run", got.Status.TicketID)
+			}
+			if !meta.IsStatusConditionTrue(got.Status.Conditions, v1alpha1.ConditionLastRunSucceeded) {
+				t.Errorf("conditions = %v, want %s true", got.Status.Conditions, v1alpha1.ConditionLastRunSucceeded)
+			}
+			if got.Status.LastScheduleTime == nil || !got.Status.LastScheduleTime.Time.Equal(scheduled) {
+				t.Errorf("status.lastScheduleTime = %v, want %s", got.Status.LastScheduleTime, scheduled)
+			}
+		})
+	}
+}
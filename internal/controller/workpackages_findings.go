@@ -0,0 +1,220 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	"github.com/shrapk2/openproject-operator/internal/openproject"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// DefaultFindingsLimit caps the child work packages created per run
+const DefaultFindingsLimit = 50
+
+// Finding is an actionable inventory item, available to templates as .Finding
+type Finding struct {
+	// Rule is the check that matched
+	Rule string
+	// Resource is the kind of item, e.g. "S3 bucket"
+	Resource string
+	// ID identifies the item, e.g. the bucket name or allocation ID
+	ID string
+	// Title is a one-line description, the default child subject
+	Title string
+	// Region is the AWS region, when known
+	Region string
+	// Details are extra properties listed in the child description
+	Details map[string]string
+	// Tags are the item's tags
+	Tags map[string]string
+}
+
+// FindingTemplateContext is the data available to the findings subject template
+type FindingTemplateContext struct {
+	TicketTemplateContext
+	Finding Finding
+}
+
+// findingRules returns the rules to evaluate, defaulting to all of them
+func findingRules(spec *v1alpha1.FindingsSpec) []v1alpha1.FindingRule {
+	if len(spec.Rules) > 0 {
+		return spec.Rules
+	}
+	return []v1alpha1.FindingRule{
+		v1alpha1.FindingPublicS3Bucket,
+		v1alpha1.FindingPublicRDSInstance,
+		v1alpha1.FindingUnassociatedEIP,
+	}
+}
+
+// collectFindings evaluates the rules against an inventory report
+func collectFindings(report *v1alpha1.CloudInventoryReport, rules []v1alpha1.FindingRule) []Finding {
+	var findings []Finding
+	for _, rule := range rules {
+		switch rule {
+		case v1alpha1.FindingPublicS3Bucket:
+			for _, b := range report.Status.S3 {
+				if b.BlockAllPublicAccess {
+					continue
+				}
+				findings = append(findings, Finding{
+					Rule:     string(rule),
+					Resource: "S3 bucket",
+					ID:       b.Name,
+					Title:    fmt.Sprintf("S3 bucket %s allows public access", b.Name),
+					Region:   b.Region,
+					Details:  map[string]string{"BlockAllPublicAccess": "false"},
+					Tags:     b.Tags,
+				})
+			}
+		case v1alpha1.FindingPublicRDSInstance:
+			for _, db := range report.Status.RDS {
+				if !db.PubliclyAccessible {
+					continue
+				}
+				findings = append(findings, Finding{
+					Rule:     string(rule),
+					Resource: "RDS instance",
+					ID:       db.DBInstanceIdentifier,
+					Title:    fmt.Sprintf("RDS instance %s is publicly accessible", db.DBInstanceIdentifier),
+					Details: map[string]string{
+						"Engine":           db.Engine + " " + db.EngineVersion,
+						"AvailabilityZone": db.AvailabilityZone,
+						"VPC":              db.VPCID,
+					},
+					Tags: db.Tags,
+				})
+			}
+		case v1alpha1.FindingUnassociatedEIP:
+			for _, eip := range report.Status.EIP {
+				if eip.InstanceID != "" || eip.NetworkInterfaceID != "" {
+					continue
+				}
+				findings = append(findings, Finding{
+					Rule:     string(rule),
+					Resource: "Elastic IP",
+					ID:       eip.AllocationID,
+					Title:    fmt.Sprintf("Elastic IP %s is not associated", eip.PublicIP),
+					Details:  map[string]string{"PublicIP": eip.PublicIP, "Domain": eip.Domain},
+					Tags:     eip.Tags,
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// findingDescription renders the details of a finding as markdown
+func findingDescription(f Finding, parentID int) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("**%s** `%s` was reported by rule `%s` in the inventory of #%d.\n\n", f.Resource, f.ID, f.Rule, parentID))
+
+	rows := map[string]string{}
+	for k, v := range f.Details {
+		if v != "" {
+			rows[k] = v
+		}
+	}
+	if f.Region != "" {
+		rows["Region"] = f.Region
+	}
+	if len(f.Tags) > 0 {
+		tags := make([]string, 0, len(f.Tags))
+		for k, v := range f.Tags {
+			tags = append(tags, k+"="+v)
+		}
+		sort.Strings(tags)
+		rows["Tags"] = strings.Join(tags, ", ")
+	}
+	if len(rows) > 0 {
+		table, _ := markdownTable(rows, "Property", "Value")
+		b.WriteString(table)
+	}
+	return b.String()
+}
+
+// createFindings creates a child work package under the run's ticket for each finding it does not
+// have yet and returns how many the ticket has. Failures are reported as events and never undo the ticket.
+func (r *WorkPackageReconciler) createFindings(ctx context.Context, wp *v1alpha1.WorkPackages, op *openproject.Client, parentID int, run ticketRun, report *v1alpha1.CloudInventoryReport, log logr.Logger) int {
+	spec := wp.Spec.Findings
+	findings := collectFindings(report, findingRules(spec))
+	if len(findings) == 0 {
+		return 0
+	}
+
+	limit := DefaultFindingsLimit
+	if spec.Limit != nil {
+		limit = int(*spec.Limit)
+	}
+	if len(findings) > limit {
+		statusLog(log, "⚠", "Too many findings; creating the first ones only", "findings", len(findings), "limit", limit)
+		r.Recorder.Eventf(wp, corev1.EventTypeWarning, v1alpha1.ReasonFindingFailed,
+			"%d findings exceed the limit of %d; only the first %d were created", len(findings), limit, limit)
+		findings = findings[:limit]
+	}
+
+	subjectTemplate := spec.Subject
+	if subjectTemplate == "" {
+		subjectTemplate = "{{ .Finding.Title }}"
+	}
+	typeID := spec.TypeID
+	if typeID == 0 {
+		typeID = wp.Status.ResolvedTypeID
+	}
+	base := newTicketTemplateContext(wp, run.Scheduled, report)
+
+	// Children created by an earlier attempt of this run are recognized by their subject
+	children, err := op.ListWorkPackages(ctx, openproject.ListOptions{
+		Filters: []openproject.Filter{
+			{Name: "parent", Operator: "=", Values: []string{strconv.Itoa(parentID)}},
+			{Name: "status", Operator: "*"},
+		},
+	})
+	if err != nil {
+		log.Error(err, "❌ Failed to list existing findings", "ticketID", parentID)
+		r.Recorder.Eventf(wp, corev1.EventTypeWarning, v1alpha1.ReasonFindingFailed,
+			"Failed to list children of ticket #%d: %v", parentID, err)
+		return 0
+	}
+	existing := make(map[string]bool, len(children))
+	for _, child := range children {
+		existing[child.Subject] = true
+	}
+
+	created := 0
+	for _, f := range findings {
+		subject, err := renderTicketTemplate("findings subject", subjectTemplate, FindingTemplateContext{TicketTemplateContext: base, Finding: f})
+		subject = strings.TrimSpace(subject)
+		if err == nil && existing[subject] {
+			created++
+			continue
+		}
+		if err == nil {
+			payload := map[string]interface{}{
+				"subject":     subject,
+				"description": openproject.Markdown(findingDescription(f, parentID)),
+				"_links": map[string]interface{}{
+					"project": openproject.LinkTo("projects", wp.Status.ResolvedProjectID),
+					"type":    openproject.LinkTo("types", typeID),
+					"parent":  openproject.LinkTo("work_packages", parentID),
+				},
+			}
+			_, err = op.CreateWorkPackage(ctx, payload)
+		}
+		if err != nil {
+			log.Error(err, "❌ Failed to create finding", "rule", f.Rule, "id", f.ID)
+			r.Recorder.Eventf(wp, corev1.EventTypeWarning, v1alpha1.ReasonFindingFailed,
+				"Failed to create child of ticket #%d for %s %s: %v", parentID, f.Resource, f.ID, err)
+			continue
+		}
+		created++
+	}
+
+	statusLog(log, "🧩", "Created findings", "ticketID", parentID, "created", created, "findings", len(findings))
+	return created
+}
@@ -0,0 +1,175 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// findingsReport has one finding per rule next to items that are fine
+func findingsReport() *v1alpha1.CloudInventoryReport {
+	return &v1alpha1.CloudInventoryReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "inventory"},
+		Status: v1alpha1.CloudInventoryReportStatus{
+			S3: []v1alpha1.S3BucketInfo{
+				{Name: "private", Region: "eu-central-1", BlockAllPublicAccess: true},
+				{Name: "public", Region: "eu-central-1", Tags: map[string]string{"team": "web", "env": "prod"}},
+			},
+			RDS: []v1alpha1.RDSInstanceInfo{
+				{DBInstanceIdentifier: "internal"},
+				{DBInstanceIdentifier: "exposed", PubliclyAccessible: true, Engine: "postgres", EngineVersion: "16"},
+			},
+			EIP: []v1alpha1.EIPInfo{
+				{AllocationID: "eipalloc-1", PublicIP: "198.51.100.1", InstanceID: "i-1"},
+				{AllocationID: "eipalloc-2", PublicIP: "198.51.100.2"},
+			},
+		},
+	}
+}
+
+func TestCollectFindings(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules []v1alpha1.FindingRule
+		want  []string
+	}{
+		{
+			name:  "all rules",
+			rules: findingRules(&v1alpha1.FindingsSpec{}),
+			want:  []string{"public", "exposed", "eipalloc-2"},
+		},
+		{
+			name:  "selected rule",
+			rules: []v1alpha1.FindingRule{v1alpha1.FindingUnassociatedEIP},
+			want:  []string{"eipalloc-2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := collectFindings(findingsReport(), tt.rules)
+			var ids []string
+			for _, f := range findings {
+				ids = append(ids, f.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("collectFindings() = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+
+	description := findingDescription(collectFindings(findingsReport(), []v1alpha1.FindingRule{v1alpha1.FindingPublicS3Bucket})[0], 42)
+	for _, want := range []string{"`public`", "#42", "eu-central-1", "env=prod, team=web"} {
+		if !strings.Contains(description, want) {
+			t.Errorf("findingDescription() = %q, want it to contain %q", description, want)
+		}
+	}
+}
+
+func TestCreateFindings(t *testing.T) {
+	one := int32(1)
+	noChildren := `{"total":0,"count":0,"_embedded":{"elements":[]}}`
+
+	tests := []struct {
+		name        string
+		spec        v1alpha1.FindingsSpec
+		responses   map[string]string
+		wantCreated int
+		wantPosts   int
+		wantSubject string
+		wantEvents  int
+	}{
+		{
+			name:        "child per finding",
+			spec:        v1alpha1.FindingsSpec{Enabled: true},
+			responses:   map[string]string{"GET /work_packages": noChildren, "POST /work_packages": `{"id":50}`},
+			wantCreated: 3,
+			wantPosts:   3,
+			wantSubject: "S3 bucket public allows public access",
+		},
+		{
+			name:        "subject template",
+			spec:        v1alpha1.FindingsSpec{Enabled: true, Subject: "[{{ .Finding.Rule }}] {{ .Finding.ID }}"},
+			responses:   map[string]string{"GET /work_packages": noChildren, "POST /work_packages": `{"id":50}`},
+			wantCreated: 3,
+			wantPosts:   3,
+			wantSubject: "[publicS3Bucket] public",
+		},
+		{
+			name:        "limit",
+			spec:        v1alpha1.FindingsSpec{Enabled: true, Limit: &one},
+			responses:   map[string]string{"GET /work_packages": noChildren, "POST /work_packages": `{"id":50}`},
+			wantCreated: 1,
+			wantPosts:   1,
+			wantSubject: "S3 bucket public allows public access",
+			wantEvents:  1,
+		},
+		{
+			name:       "creation fails",
+			spec:       v1alpha1.FindingsSpec{Enabled: true, Rules: []v1alpha1.FindingRule{v1alpha1.FindingPublicS3Bucket}},
+			responses:  map[string]string{"GET /work_packages": noChildren},
+			wantPosts:  1,
+			wantEvents: 1,
+		},
+		{
+			name: "existing child is kept",
+			spec: v1alpha1.FindingsSpec{Enabled: true, Rules: []v1alpha1.FindingRule{v1alpha1.FindingPublicS3Bucket}},
+			responses: map[string]string{
+				"GET /work_packages": `{"total":1,"count":1,"_embedded":{"elements":[{"id":50,"subject":"S3 bucket public allows public access"}]}}`,
+			},
+			wantCreated: 1,
+		},
+		{
+			name:       "children cannot be listed",
+			spec:       v1alpha1.FindingsSpec{Enabled: true},
+			wantEvents: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeOpenProject(t, tt.responses)
+			r := newTestWorkPackageReconciler(t)
+			wp := &v1alpha1.WorkPackages{
+				Spec:   v1alpha1.WorkPackagesSpec{Findings: &tt.spec},
+				Status: v1alpha1.WorkPackagesStatus{ResolvedProjectID: 7, ResolvedTypeID: 1},
+			}
+
+			created := r.createFindings(context.Background(), wp, f.client(), 42, ticketRun{RunKey: "run-key"}, findingsReport(), logr.Discard())
+			if created != tt.wantCreated {
+				t.Errorf("createFindings() = %d, want %d", created, tt.wantCreated)
+			}
+			if events := recordedEvents(r.Recorder); len(events) != tt.wantEvents {
+				t.Errorf("events = %v, want %d", events, tt.wantEvents)
+			}
+			writes := f.writes()
+			if len(writes) != tt.wantPosts {
+				t.Errorf("createFindings() writes = %v, want %d", writes, tt.wantPosts)
+			}
+			if tt.wantSubject == "" {
+				return
+			}
+
+			var payload struct {
+				Subject string `json:"subject"`
+				Links   map[string]struct {
+					Href string `json:"href"`
+				} `json:"_links"`
+			}
+			if err := json.Unmarshal([]byte(f.received()[1].Body), &payload); err != nil {
+				t.Fatal(err)
+			}
+			if payload.Subject != tt.wantSubject {
+				t.Errorf("subject = %q, want %q", payload.Subject, tt.wantSubject)
+			}
+			if payload.Links["parent"].Href != "/api/v3/work_packages/42" || payload.Links["project"].Href != "/api/v3/projects/7" {
+				t.Errorf("links = %+v, want the run's ticket as parent in project 7", payload.Links)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestAppendRunHistory(t *testing.T) {
+	history := []v1alpha1.WorkPackageRunRecord{{RunKey: "b"}, {RunKey: "a"}}
+
+	tests := []struct {
+		name  string
+		limit int
+		want  []string
+	}{
+		{name: "below limit", limit: 10, want: []string{"c", "b", "a"}},
+		{name: "at limit", limit: 3, want: []string{"c", "b", "a"}},
+		{name: "oldest dropped", limit: 2, want: []string{"c", "b"}},
+		{name: "history disabled", limit: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := append([]v1alpha1.WorkPackageRunRecord(nil), history...)
+			got := appendRunHistory(input, v1alpha1.WorkPackageRunRecord{RunKey: "c"}, tt.limit)
+			var keys []string
+			for _, record := range got {
+				keys = append(keys, record.RunKey)
+			}
+			if len(keys) != len(tt.want) {
+				t.Fatalf("appendRunHistory() = %v, want %v", keys, tt.want)
+			}
+			for i := range keys {
+				if keys[i] != tt.want[i] {
+					t.Errorf("appendRunHistory() = %v, want %v", keys, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestHistoryLimit(t *testing.T) {
+	zero := int32(0)
+	three := int32(3)
+
+	tests := []struct {
+		name  string
+		limit *int32
+		want  int
+	}{
+		{name: "unset", want: DefaultHistoryLimit},
+		{name: "disabled", limit: &zero, want: 0},
+		{name: "set", limit: &three, want: 3},
+	}
+
+	for _, tt := range tests {
+		wp := &v1alpha1.WorkPackages{Spec: v1alpha1.WorkPackagesSpec{HistoryLimit: tt.limit}}
+		if got := historyLimit(wp); got != tt.want {
+			t.Errorf("%s: historyLimit() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHandleCreateTicketHistory(t *testing.T) {
+	empty := `{"total":0,"count":0,"_embedded":{"elements":[]}}`
+
+	tests := []struct {
+		name        string
+		responses   map[string]string
+		wantOutcome string
+		wantTicket  string
+		wantStatus  int
+		wantEvent   string
+	}{
+		{
+			name:        "created",
+			responses:   map[string]string{"GET /work_packages": empty, "POST /work_packages": `{"id":42}`},
+			wantOutcome: RunOutcomeCreated,
+			wantTicket:  "42",
+			wantStatus:  201,
+			wantEvent:   "Normal " + v1alpha1.ReasonTicketCreated,
+		},
+		{
+			name: "existing",
+			responses: map[string]string{
+				"GET /work_packages": `{"total":1,"count":1,"_embedded":{"elements":[{"id":41}]}}`,
+			},
+			wantOutcome: RunOutcomeExisting,
+			wantTicket:  "41",
+			wantEvent:   "Normal " + v1alpha1.ReasonTicketExists,
+		},
+		{
+			name:        "rejected",
+			responses:   map[string]string{"GET /work_packages": empty},
+			wantOutcome: RunOutcomeFailed,
+			wantStatus:  404,
+			wantEvent:   "Warning " + v1alpha1.ReasonTicketCreationFailed,
+		},
+		{
+			name:        "run key search rejected",
+			wantOutcome: RunOutcomeFailed,
+			wantStatus:  404,
+			wantEvent:   "Warning " + v1alpha1.ReasonTicketCreationFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeOpenProject(t, tt.responses)
+			config, secret := testServerConfig("default", f.URL)
+			wp := &v1alpha1.WorkPackages{
+				ObjectMeta: metav1.ObjectMeta{Name: "patching", Namespace: "default", UID: "8f0c"},
+				Spec: v1alpha1.WorkPackagesSpec{
+					Schedule:        "0 7 * * *",
+					Subject:         "Patch review",
+					ProjectID:       7,
+					TypeID:          1,
+					ServerConfigRef: v1alpha1.ServerConfigRef{Name: config.Name},
+				},
+				Status: v1alpha1.WorkPackagesStatus{
+					History: []v1alpha1.WorkPackageRunRecord{{RunKey: "older", Outcome: RunOutcomeCreated}},
+				},
+			}
+			r := newTestWorkPackageReconciler(t, wp, config, secret)
+
+			run := newManualRun(wp, "now", time.UTC)
+			if _, err := r.handleCreateTicket(context.Background(), wp, f.client(), &ticketTargets{}, run, logr.Discard()); err != nil {
+				t.Fatalf("handleCreateTicket() error = %v", err)
+			}
+
+			var got v1alpha1.WorkPackages
+			if err := r.Get(context.Background(), client.ObjectKeyFromObject(wp), &got); err != nil {
+				t.Fatal(err)
+			}
+			if len(got.Status.History) != 2 {
+				t.Fatalf("history has %d runs, want 2", len(got.Status.History))
+			}
+			latest := got.Status.History[0]
+			if latest.RunKey != run.RunKey || latest.Outcome != tt.wantOutcome {
+				t.Errorf("latest run = %s %s, want %s %s", latest.RunKey, latest.Outcome, run.RunKey, tt.wantOutcome)
+			}
+			if latest.TicketID != tt.wantTicket || latest.HTTPStatus != tt.wantStatus {
+				t.Errorf("latest run ticket %q status %d, want %q %d", latest.TicketID, latest.HTTPStatus, tt.wantTicket, tt.wantStatus)
+			}
+			if tt.wantTicket != "" && latest.TicketURL != f.URL+"/work_packages/"+tt.wantTicket {
+				t.Errorf("latest run ticketURL = %q", latest.TicketURL)
+			}
+			if latest.Duration == nil {
+				t.Error("latest run has no duration")
+			}
+			if events := recordedEvents(r.Recorder); len(events) != 1 || !strings.HasPrefix(events[0], tt.wantEvent+" ") {
+				t.Errorf("events = %v, want one %q", events, tt.wantEvent)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	"github.com/shrapk2/openproject-operator/internal/openproject"
+)
+
+// Constants for previous ticket policies
+const (
+	PreviousTicketLeave           = "leave"
+	PreviousTicketClose           = "close"
+	PreviousTicketRelate          = "relate"
+	PreviousTicketCommentAndClose = "commentAndClose"
+)
+
+// applyPreviousTicketPolicy acts on the ticket created by the previous run once a new one exists
+func applyPreviousTicketPolicy(ctx context.Context, wp *v1alpha1.WorkPackages, op *openproject.Client, newID int, log logr.Logger) error {
+	policy := wp.Spec.PreviousTicketPolicy
+	if policy == "" || policy == PreviousTicketLeave || wp.Status.TicketID == "" {
+		return nil
+	}
+	previousID, err := strconv.Atoi(wp.Status.TicketID)
+	if err != nil {
+		return fmt.Errorf("invalid previous ticket ID %q: %w", wp.Status.TicketID, err)
+	}
+	// Runs in comment mode may have recorded the long-lived tracking ticket before it was kept apart
+	if previousID == newID || wp.Status.TicketID == wp.Status.CommentTicketID || previousID == wp.Spec.TargetWorkPackageID {
+		return nil
+	}
+
+	closing := policy == PreviousTicketClose || policy == PreviousTicketCommentAndClose
+	if closing && wp.Spec.PreviousTicketStatusID == 0 {
+		return fmt.Errorf("previousTicketStatusID is required for policy %q", policy)
+	}
+	if !closing && policy != PreviousTicketRelate {
+		return fmt.Errorf("unknown previousTicketPolicy %q", policy)
+	}
+
+	// A retried run finds the policy already applied to the previous ticket
+	if closing {
+		previous, err := op.GetWorkPackage(ctx, previousID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch ticket %d: %w", previousID, err)
+		}
+		if previous.Links.Status.ID() == wp.Spec.PreviousTicketStatusID {
+			return nil
+		}
+	} else {
+		relations, err := op.ListRelations(ctx, newID)
+		if err != nil {
+			return fmt.Errorf("failed to list relations of ticket %d: %w", newID, err)
+		}
+		if slices.ContainsFunc(relations, func(rel openproject.Relation) bool { return rel.Connects("relates", newID, previousID) }) {
+			return nil
+		}
+	}
+
+	comment := wp.Spec.PreviousTicketComment
+	if policy == PreviousTicketCommentAndClose && comment == "" {
+		comment = fmt.Sprintf("Superseded by #%d", newID)
+	}
+
+	if comment != "" {
+		if _, err := op.AddComment(ctx, previousID, comment); err != nil {
+			return fmt.Errorf("failed to comment on ticket %d: %w", previousID, err)
+		}
+	}
+
+	if closing {
+		if err := op.SetWorkPackageStatus(ctx, previousID, wp.Spec.PreviousTicketStatusID); err != nil {
+			return fmt.Errorf("failed to update status of ticket %d: %w", previousID, err)
+		}
+	} else if _, err := op.CreateRelation(ctx, newID, previousID, "relates"); err != nil {
+		return fmt.Errorf("failed to relate ticket %d to %d: %w", newID, previousID, err)
+	}
+
+	statusLog(log, "🔗", "Applied previous ticket policy", "policy", policy, "previousTicketID", previousID)
+	return nil
+}
@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/go-logr/logr"
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+)
+
+func TestApplyPreviousTicketPolicy(t *testing.T) {
+	openPrevious := `{"id":7,"lockVersion":3,"_links":{"status":{"href":"/api/v3/statuses/1"}}}`
+	closedPrevious := `{"id":7,"lockVersion":4,"_links":{"status":{"href":"/api/v3/statuses/12"}}}`
+	noRelations := `{"total":0,"count":0,"_embedded":{"elements":[]}}`
+	related := `{"total":1,"count":1,"_embedded":{"elements":[{"id":3,"type":"relates",` +
+		`"_links":{"from":{"href":"/api/v3/work_packages/7"},"to":{"href":"/api/v3/work_packages/8"}}}]}}`
+
+	tests := []struct {
+		name       string
+		spec       v1alpha1.WorkPackagesSpec
+		status     v1alpha1.WorkPackagesStatus
+		responses  map[string]string
+		wantWrites []string
+		wantErr    bool
+	}{
+		{
+			name:   "leave does nothing",
+			spec:   v1alpha1.WorkPackagesSpec{PreviousTicketPolicy: PreviousTicketLeave},
+			status: v1alpha1.WorkPackagesStatus{TicketID: "7"},
+		},
+		{
+			name: "no previous ticket",
+			spec: v1alpha1.WorkPackagesSpec{PreviousTicketPolicy: PreviousTicketClose, PreviousTicketStatusID: 12},
+		},
+		{
+			name:   "previous ticket is the new ticket",
+			spec:   v1alpha1.WorkPackagesSpec{PreviousTicketPolicy: PreviousTicketClose, PreviousTicketStatusID: 12},
+			status: v1alpha1.WorkPackagesStatus{TicketID: "8"},
+		},
+		{
+			name:   "previous ticket is the comment mode tracking ticket",
+			spec:   v1alpha1.WorkPackagesSpec{PreviousTicketPolicy: PreviousTicketClose, PreviousTicketStatusID: 12},
+			status: v1alpha1.WorkPackagesStatus{TicketID: "7", CommentTicketID: "7"},
+		},
+		{
+			name:    "close without status",
+			spec:    v1alpha1.WorkPackagesSpec{PreviousTicketPolicy: PreviousTicketClose},
+			status:  v1alpha1.WorkPackagesStatus{TicketID: "7"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown policy",
+			spec:    v1alpha1.WorkPackagesSpec{PreviousTicketPolicy: "archive"},
+			status:  v1alpha1.WorkPackagesStatus{TicketID: "7"},
+			wantErr: true,
+		},
+		{
+			name:       "close",
+			spec:       v1alpha1.WorkPackagesSpec{PreviousTicketPolicy: PreviousTicketClose, PreviousTicketStatusID: 12},
+			status:     v1alpha1.WorkPackagesStatus{TicketID: "7"},
+			responses:  map[string]string{"GET /work_packages/7": openPrevious, "PATCH /work_packages/7": closedPrevious},
+			wantWrites: []string{"PATCH /work_packages/7"},
+		},
+		{
+			name:      "close an already closed ticket",
+			spec:      v1alpha1.WorkPackagesSpec{PreviousTicketPolicy: PreviousTicketClose, PreviousTicketStatusID: 12},
+			status:    v1alpha1.WorkPackagesStatus{TicketID: "7"},
+			responses: map[string]string{"GET /work_packages/7": closedPrevious},
+		},
+		{
+			name:   "comment and close",
+			spec:   v1alpha1.WorkPackagesSpec{PreviousTicketPolicy: PreviousTicketCommentAndClose, PreviousTicketStatusID: 12},
+			status: v1alpha1.WorkPackagesStatus{TicketID: "7"},
+			responses: map[string]string{
+				"GET /work_packages/7":             openPrevious,
+				"POST /work_packages/7/activities": `{"id":1}`,
+				"PATCH /work_packages/7":           closedPrevious,
+			},
+			wantWrites: []string{"POST /work_packages/7/activities", "PATCH /work_packages/7"},
+		},
+		{
+			name:   "relate",
+			spec:   v1alpha1.WorkPackagesSpec{PreviousTicketPolicy: PreviousTicketRelate},
+			status: v1alpha1.WorkPackagesStatus{TicketID: "7"},
+			responses: map[string]string{
+				"GET /work_packages/8/relations":  noRelations,
+				"POST /work_packages/8/relations": `{"id":3,"type":"relates"}`,
+			},
+			wantWrites: []string{"POST /work_packages/8/relations"},
+		},
+		{
+			name:      "relate an already related ticket",
+			spec:      v1alpha1.WorkPackagesSpec{PreviousTicketPolicy: PreviousTicketRelate},
+			status:    v1alpha1.WorkPackagesStatus{TicketID: "7"},
+			responses: map[string]string{"GET /work_packages/8/relations": related},
+		},
+		{
+			name:    "previous ticket cannot be fetched",
+			spec:    v1alpha1.WorkPackagesSpec{PreviousTicketPolicy: PreviousTicketClose, PreviousTicketStatusID: 12},
+			status:  v1alpha1.WorkPackagesStatus{TicketID: "7"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeOpenProject(t, tt.responses)
+			wp := &v1alpha1.WorkPackages{Spec: tt.spec, Status: tt.status}
+
+			err := applyPreviousTicketPolicy(context.Background(), wp, f.client(), 8, logr.Discard())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyPreviousTicketPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if writes := f.writes(); !slices.Equal(writes, tt.wantWrites) {
+				t.Errorf("applyPreviousTicketPolicy() writes = %v, want %v", writes, tt.wantWrites)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	"github.com/shrapk2/openproject-operator/internal/openproject"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// principalMatches reports whether a principal is the one a login, email or name refers to
+func principalMatches(p openproject.Principal, ref string) bool {
+	return strings.EqualFold(p.Login, ref) || strings.EqualFold(p.Email, ref) || strings.EqualFold(p.Name, ref)
+}
+
+// principalResource is the API resource of a principal type
+func principalResource(principalType string) string {
+	if principalType == openproject.PrincipalGroup {
+		return "groups"
+	}
+	return "users"
+}
+
+// resolvePrincipal finds a user by login or email, or a group by name. Groups are only
+// accepted when allowGroups is set; watchers must be users.
+func resolvePrincipal(ctx context.Context, op *openproject.Client, config *v1alpha1.ServerConfig, ref string, allowGroups bool) (*openproject.Link, error) {
+	kinds := []string{openproject.PrincipalUser}
+	if allowGroups {
+		kinds = append(kinds, openproject.PrincipalGroup)
+	}
+	for _, kind := range kinds {
+		if id, ok := resolvedIDs.get(resolveCacheKey(config, kind, ref)); ok {
+			return openproject.LinkTo(principalResource(kind), id), nil
+		}
+	}
+
+	principals, err := op.ListPrincipals(ctx, openproject.ListOptions{
+		Filters: []openproject.Filter{
+			{Name: "type", Operator: "=", Values: kinds},
+			{Name: "any_name_attribute", Operator: "~", Values: []string{ref}},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search principals for %q: %w", ref, err)
+	}
+	for _, p := range principals {
+		if !principalMatches(p, ref) {
+			continue
+		}
+		resolvedIDs.put(resolveCacheKey(config, p.Type, ref), p.ID)
+		return openproject.LinkTo(principalResource(p.Type), p.ID), nil
+	}
+
+	if allowGroups {
+		return nil, fmt.Errorf("no user or group matches %q", ref)
+	}
+	return nil, fmt.Errorf("no user matches %q", ref)
+}
+
+// resolvePrincipals resolves the assignee, responsible and watchers into targets
+func resolvePrincipals(ctx context.Context, op *openproject.Client, config *v1alpha1.ServerConfig, spec *v1alpha1.WorkPackagesSpec, targets *ticketTargets) error {
+	var err error
+	if spec.Assignee != "" {
+		if targets.Assignee, err = resolvePrincipal(ctx, op, config, spec.Assignee, true); err != nil {
+			return fmt.Errorf("assignee: %w", err)
+		}
+	}
+	if spec.Responsible != "" {
+		if targets.Responsible, err = resolvePrincipal(ctx, op, config, spec.Responsible, true); err != nil {
+			return fmt.Errorf("responsible: %w", err)
+		}
+	}
+	for _, watcher := range spec.Watchers {
+		link, err := resolvePrincipal(ctx, op, config, watcher, false)
+		if err != nil {
+			return fmt.Errorf("watcher: %w", err)
+		}
+		targets.WatcherIDs = append(targets.WatcherIDs, link.ID())
+	}
+	return nil
+}
+
+// hasPrincipals reports whether the spec names an assignee, responsible or watchers
+func hasPrincipals(wp *v1alpha1.WorkPackages) bool {
+	return wp.Spec.Assignee != "" || wp.Spec.Responsible != "" || len(wp.Spec.Watchers) > 0
+}
+
+// addWatchers adds the resolved watchers that are not watching the ticket yet; failures are
+// reported but never undo the ticket
+func (r *WorkPackageReconciler) addWatchers(ctx context.Context, wp *v1alpha1.WorkPackages, op *openproject.Client, ticketID int, watcherIDs []int, log logr.Logger) {
+	if len(watcherIDs) == 0 {
+		return
+	}
+	watching, err := op.ListWatchers(ctx, ticketID)
+	if err != nil {
+		log.Error(err, "❌ Failed to list watchers", "ticketID", ticketID)
+		r.Recorder.Eventf(wp, corev1.EventTypeWarning, v1alpha1.ReasonWatcherFailed,
+			"Failed to list watchers of ticket #%d: %v", ticketID, err)
+		return
+	}
+
+	added := 0
+	for _, userID := range watcherIDs {
+		if slices.ContainsFunc(watching, func(u openproject.User) bool { return u.ID == userID }) {
+			continue
+		}
+		if err := op.AddWatcher(ctx, ticketID, userID); err != nil {
+			log.Error(err, "❌ Failed to add watcher", "ticketID", ticketID, "userID", userID)
+			r.Recorder.Eventf(wp, corev1.EventTypeWarning, v1alpha1.ReasonWatcherFailed,
+				"Failed to add user %d as watcher of ticket #%d: %v", userID, ticketID, err)
+			continue
+		}
+		added++
+	}
+	if added > 0 {
+		statusLog(log, "👀", "Added watchers", "ticketID", ticketID, "count", added)
+	}
+}
@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResolvePrincipal(t *testing.T) {
+	principals := `{"total":3,"count":3,"_embedded":{"elements":[` +
+		`{"_type":"User","id":4,"name":"Ada Lovelace","login":"ada","email":"ada@example.com"},` +
+		`{"_type":"User","id":5,"name":"Adam Smith","login":"adam","email":"adam@example.com"},` +
+		`{"_type":"Group","id":9,"name":"Platform"}]}}`
+
+	tests := []struct {
+		name        string
+		ref         string
+		allowGroups bool
+		wantHref    string
+		wantTypes   string
+		wantErr     bool
+	}{
+		{name: "user by login", ref: "adam", wantHref: "/api/v3/users/5", wantTypes: "User"},
+		{name: "user by email", ref: "ADA@example.com", wantHref: "/api/v3/users/4", wantTypes: "User"},
+		{name: "group by name", ref: "platform", allowGroups: true, wantHref: "/api/v3/groups/9", wantTypes: "User,Group"},
+		{name: "partial match is not enough", ref: "ad", wantTypes: "User", wantErr: true},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeOpenProject(t, map[string]string{"GET /principals": principals})
+			// Each case gets its own cache scope
+			config := &v1alpha1.ServerConfig{ObjectMeta: metav1.ObjectMeta{Name: "principals", Namespace: "test", Generation: int64(i + 1)}}
+
+			link, err := resolvePrincipal(context.Background(), f.client(), config, tt.ref, tt.allowGroups)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolvePrincipal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && link.Href != tt.wantHref {
+				t.Errorf("resolvePrincipal() = %s, want %s", link.Href, tt.wantHref)
+			}
+			filters := f.received()[0].Query.Get("filters")
+			if !strings.Contains(filters, `"values":["`+strings.ReplaceAll(tt.wantTypes, ",", `","`)+`"]`) {
+				t.Errorf("filters = %s, want types %s", filters, tt.wantTypes)
+			}
+			if err != nil {
+				return
+			}
+
+			// A resolved reference is served from the cache
+			if _, err := resolvePrincipal(context.Background(), f.client(), config, tt.ref, tt.allowGroups); err != nil {
+				t.Fatal(err)
+			}
+			if n := len(f.received()); n != 1 {
+				t.Errorf("second lookup sent %d requests in total, want 1", n)
+			}
+		})
+	}
+}
+
+func TestResolvePrincipals(t *testing.T) {
+	f := newFakeOpenProject(t, map[string]string{
+		"GET /principals": `{"total":2,"count":2,"_embedded":{"elements":[` +
+			`{"_type":"User","id":4,"name":"Ada Lovelace","login":"ada"},{"_type":"Group","id":9,"name":"Platform"}]}}`,
+	})
+	config := &v1alpha1.ServerConfig{ObjectMeta: metav1.ObjectMeta{Name: "principals-spec", Namespace: "test"}}
+
+	spec := &v1alpha1.WorkPackagesSpec{Assignee: "Platform", Responsible: "ada", Watchers: []string{"ada"}}
+	var targets ticketTargets
+	if err := resolvePrincipals(context.Background(), f.client(), config, spec, &targets); err != nil {
+		t.Fatalf("resolvePrincipals() error = %v", err)
+	}
+	if targets.Assignee.Href != "/api/v3/groups/9" || targets.Responsible.Href != "/api/v3/users/4" || !slices.Equal(targets.WatcherIDs, []int{4}) {
+		t.Errorf("resolvePrincipals() = %+v", targets)
+	}
+
+	spec = &v1alpha1.WorkPackagesSpec{Watchers: []string{"grace"}}
+	if err := resolvePrincipals(context.Background(), f.client(), config, spec, &ticketTargets{}); err == nil || !strings.HasPrefix(err.Error(), "watcher:") {
+		t.Errorf("resolvePrincipals() error = %v, want a watcher error", err)
+	}
+}
+
+func TestAddWatchers(t *testing.T) {
+	f := newFakeOpenProject(t, map[string]string{
+		"GET /work_packages/42/watchers":  `{"total":1,"count":1,"_embedded":{"elements":[{"_type":"User","id":4}]}}`,
+		"POST /work_packages/42/watchers": `{}`,
+	})
+	r := newTestWorkPackageReconciler(t)
+	wp := &v1alpha1.WorkPackages{}
+
+	// User 4 already watches the ticket
+	r.addWatchers(context.Background(), wp, f.client(), 42, []int{4, 5}, logr.Discard())
+	if writes := f.writes(); len(writes) != 1 || !strings.Contains(f.received()[1].Body, "/api/v3/users/5") {
+		t.Errorf("addWatchers() writes = %v, want one request adding user 5", writes)
+	}
+	if events := recordedEvents(r.Recorder); len(events) != 0 {
+		t.Errorf("events = %v, want none", events)
+	}
+
+	r.addWatchers(context.Background(), wp, f.client(), 43, []int{4}, logr.Discard())
+	if events := recordedEvents(r.Recorder); len(events) != 1 || !strings.Contains(events[0], v1alpha1.ReasonWatcherFailed) {
+		t.Errorf("events = %v, want one %s", events, v1alpha1.ReasonWatcherFailed)
+	}
+}
@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	"github.com/shrapk2/openproject-operator/internal/openproject"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Relation selectors
+const (
+	RelationSelectorPreviousRun = "previousRun"
+)
+
+// relationTarget returns the work package a relation points at, or 0 when the selector
+// has nothing to select yet
+func relationTarget(wp *v1alpha1.WorkPackages, relation v1alpha1.WorkPackageRelation) (int, error) {
+	if relation.ToID != 0 {
+		return relation.ToID, nil
+	}
+	switch relation.Selector {
+	case RelationSelectorPreviousRun:
+		// Status still holds the ticket of the previous run until the new one is recorded
+		if wp.Status.TicketID == "" {
+			return 0, nil
+		}
+		id, err := strconv.Atoi(wp.Status.TicketID)
+		if err != nil {
+			return 0, fmt.Errorf("invalid previous ticket ID %q: %w", wp.Status.TicketID, err)
+		}
+		return id, nil
+	default:
+		return 0, fmt.Errorf("unknown relation selector %q", relation.Selector)
+	}
+}
+
+// createRelations creates spec.relations from a ticket, skipping those it already has. Each
+// failure is reported in the result and as an event, but never undoes the ticket.
+func (r *WorkPackageReconciler) createRelations(ctx context.Context, wp *v1alpha1.WorkPackages, op *openproject.Client, ticketID int, log logr.Logger) []v1alpha1.WorkPackageRelationResult {
+	if len(wp.Spec.Relations) == 0 {
+		return nil
+	}
+	existing, listErr := op.ListRelations(ctx, ticketID)
+	if listErr != nil {
+		listErr = fmt.Errorf("failed to list relations: %w", listErr)
+	}
+
+	var results []v1alpha1.WorkPackageRelationResult
+	for _, relation := range wp.Spec.Relations {
+		result := v1alpha1.WorkPackageRelationResult{Type: relation.Type}
+
+		toID, err := relationTarget(wp, relation)
+		if err == nil && toID == 0 {
+			statusLog(log, "⏭", "No work package to relate to", "type", relation.Type, "selector", relation.Selector)
+			continue
+		}
+		if err == nil && toID == ticketID {
+			continue
+		}
+		result.ToID = toID
+		if err == nil {
+			err = listErr
+		}
+
+		if err == nil {
+			if i := slices.IndexFunc(existing, func(rel openproject.Relation) bool { return rel.Connects(relation.Type, ticketID, toID) }); i >= 0 {
+				result.RelationID = existing[i].ID
+				results = append(results, result)
+				continue
+			}
+			var created *openproject.Relation
+			if created, err = op.CreateRelation(ctx, ticketID, toID, relation.Type); err == nil {
+				result.RelationID = created.ID
+			}
+		}
+		if err != nil {
+			result.Error = err.Error()
+			log.Error(err, "❌ Failed to create relation", "ticketID", ticketID, "type", relation.Type, "toID", toID)
+			r.Recorder.Eventf(wp, corev1.EventTypeWarning, v1alpha1.ReasonRelationFailed,
+				"Failed to create %s relation from ticket #%d to #%d: %v", relation.Type, ticketID, toID, err)
+		} else {
+			statusLog(log, "🔗", "Created relation", "ticketID", ticketID, "type", relation.Type, "toID", toID)
+		}
+		results = append(results, result)
+	}
+	return results
+}
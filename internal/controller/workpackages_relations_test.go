@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/go-logr/logr"
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+)
+
+func TestCreateRelations(t *testing.T) {
+	// relationsOf lists one relation of ticket 8 as OpenProject returns it
+	relationsOf := func(relationType string, from, to string) string {
+		return `{"total":1,"count":1,"_embedded":{"elements":[{"id":3,"type":"` + relationType + `",` +
+			`"_links":{"from":{"href":"/api/v3/work_packages/` + from + `"},"to":{"href":"/api/v3/work_packages/` + to + `"}}}]}}`
+	}
+
+	tests := []struct {
+		name         string
+		relationType string
+		existing     string
+		wantWrites   []string
+		wantRelation int
+	}{
+		{
+			name:         "follows matches the stored precedes relation",
+			relationType: "follows",
+			existing:     relationsOf("precedes", "7", "8"),
+			wantRelation: 3,
+		},
+		{
+			name:         "partof matches the stored includes relation",
+			relationType: "partof",
+			existing:     relationsOf("includes", "7", "8"),
+			wantRelation: 3,
+		},
+		{
+			name:         "relates matches in either direction",
+			relationType: "relates",
+			existing:     relationsOf("relates", "7", "8"),
+			wantRelation: 3,
+		},
+		{
+			name:         "follows does not match precedes in the same direction",
+			relationType: "follows",
+			existing:     relationsOf("precedes", "8", "7"),
+			wantWrites:   []string{"POST /work_packages/8/relations"},
+			wantRelation: 4,
+		},
+		{
+			name:         "blocks does not match another type",
+			relationType: "blocks",
+			existing:     relationsOf("precedes", "8", "7"),
+			wantWrites:   []string{"POST /work_packages/8/relations"},
+			wantRelation: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeOpenProject(t, map[string]string{
+				"GET /work_packages/8/relations":  tt.existing,
+				"POST /work_packages/8/relations": `{"id":4,"type":"` + tt.relationType + `"}`,
+			})
+			r := newTestWorkPackageReconciler(t)
+			wp := &v1alpha1.WorkPackages{Spec: v1alpha1.WorkPackagesSpec{
+				Relations: []v1alpha1.WorkPackageRelation{{Type: tt.relationType, ToID: 7}},
+			}}
+
+			results := r.createRelations(context.Background(), wp, f.client(), 8, logr.Discard())
+			if writes := f.writes(); !slices.Equal(writes, tt.wantWrites) {
+				t.Errorf("createRelations() writes = %v, want %v", writes, tt.wantWrites)
+			}
+			if len(results) != 1 || results[0].RelationID != tt.wantRelation || results[0].Error != "" {
+				t.Errorf("createRelations() = %+v, want relation %d", results, tt.wantRelation)
+			}
+		})
+	}
+}
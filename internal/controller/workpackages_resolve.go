@@ -0,0 +1,251 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	"github.com/shrapk2/openproject-operator/internal/openproject"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ResolveCacheTTL is how long resolved project and type IDs are reused
+var ResolveCacheTTL = getDurationFromEnv("RESOLVE_CACHE_TTL", 10*time.Minute)
+
+// resolvedIDs caches project and type lookups, keyed per ServerConfig
+var resolvedIDs = &idCache{entries: map[string]cachedID{}}
+
+// cachedID is a resolved ID and when it expires
+type cachedID struct {
+	id      int
+	expires time.Time
+}
+
+// idCache is a small TTL cache of name to ID lookups
+type idCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedID
+}
+
+// get returns a cached ID that has not expired
+func (c *idCache) get(key string) (int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expires) {
+		return 0, false
+	}
+	return entry.id, true
+}
+
+// put stores an ID for ResolveCacheTTL
+func (c *idCache) put(key string, id int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = cachedID{id: id, expires: time.Now().Add(ResolveCacheTTL)}
+}
+
+// resolveCacheKey scopes a lookup to a ServerConfig; a changed spec gets fresh lookups
+func resolveCacheKey(config *v1alpha1.ServerConfig, kind, ref string) string {
+	return fmt.Sprintf("%s@%d|%s|%s", configCacheKey(config), config.Generation, kind, strings.ToLower(ref))
+}
+
+// resolveProjectID finds a project by identifier, falling back to an exact name match
+func resolveProjectID(ctx context.Context, op *openproject.Client, config *v1alpha1.ServerConfig, ref string) (int, error) {
+	key := resolveCacheKey(config, "project", ref)
+	if id, ok := resolvedIDs.get(key); ok {
+		return id, nil
+	}
+
+	project, err := op.GetProject(ctx, ref)
+	if err != nil && !openproject.IsNotFound(err) {
+		return 0, fmt.Errorf("failed to look up project %q: %w", ref, err)
+	}
+	if project == nil {
+		projects, err := op.ListProjects(ctx, openproject.ListOptions{
+			Filters: []openproject.Filter{{Name: "name_and_identifier", Operator: "~", Values: []string{ref}}},
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to search projects for %q: %w", ref, err)
+		}
+		for i := range projects {
+			if strings.EqualFold(projects[i].Name, ref) || strings.EqualFold(projects[i].Identifier, ref) {
+				project = &projects[i]
+				break
+			}
+		}
+	}
+	if project == nil {
+		return 0, fmt.Errorf("project %q not found", ref)
+	}
+
+	resolvedIDs.put(key, project.ID)
+	return project.ID, nil
+}
+
+// resolveTypeID finds a work package type by name, preferring the types enabled in the project
+func resolveTypeID(ctx context.Context, op *openproject.Client, config *v1alpha1.ServerConfig, projectID int, ref string) (int, error) {
+	key := resolveCacheKey(config, "type", strconv.Itoa(projectID)+"/"+ref)
+	if id, ok := resolvedIDs.get(key); ok {
+		return id, nil
+	}
+
+	types, err := op.ListProjectTypes(ctx, projectID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list types of project %d: %w", projectID, err)
+	}
+	for _, t := range types {
+		if strings.EqualFold(t.Name, ref) {
+			resolvedIDs.put(key, t.ID)
+			return t.ID, nil
+		}
+	}
+
+	// Tell a type that exists but is not enabled in the project from an unknown one; neither is
+	// cached, so enabling the type takes effect on the next run
+	all, err := op.ListTypes(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list types: %w", err)
+	}
+	for _, t := range all {
+		if strings.EqualFold(t.Name, ref) {
+			return 0, fmt.Errorf("type %q is not enabled in project %d", ref, projectID)
+		}
+	}
+	return 0, fmt.Errorf("type %q not found", ref)
+}
+
+// resolveWorkPackageRef finds an open work package of the project by its exact subject
+func resolveWorkPackageRef(ctx context.Context, op *openproject.Client, config *v1alpha1.ServerConfig, projectID int, subject string) (int, error) {
+	key := resolveCacheKey(config, "workPackage", strconv.Itoa(projectID)+"/"+subject)
+	if id, ok := resolvedIDs.get(key); ok {
+		return id, nil
+	}
+
+	candidates, err := op.ListWorkPackages(ctx, openproject.ListOptions{
+		Filters: []openproject.Filter{
+			{Name: "project", Operator: "=", Values: []string{strconv.Itoa(projectID)}},
+			{Name: "status", Operator: "o"},
+			{Name: "subject", Operator: "~", Values: []string{subject}},
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to search work packages for %q: %w", subject, err)
+	}
+	for _, candidate := range candidates {
+		if strings.EqualFold(candidate.Subject, subject) {
+			resolvedIDs.put(key, candidate.ID)
+			return candidate.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("no open work package with subject %q", subject)
+}
+
+// ticketTargets are the references of a ticket resolved on the server for one run
+type ticketTargets struct {
+	Assignee    *openproject.Link
+	Responsible *openproject.Link
+	WatcherIDs  []int
+	// TargetWorkPackageID is the ticket commented on in comment mode
+	TargetWorkPackageID int
+	// CustomFields and CustomFieldLinks are merged into the payload and its _links
+	CustomFields     map[string]interface{}
+	CustomFieldLinks map[string]interface{}
+}
+
+// resolveTargets resolves projectRef and typeRef, recording the IDs in status, and the
+// people the ticket is assigned to
+func (r *WorkPackageReconciler) resolveTargets(ctx context.Context, wp *v1alpha1.WorkPackages, config *v1alpha1.ServerConfig, op *openproject.Client, log logr.Logger) (*ticketTargets, error) {
+	projectID := wp.Spec.ProjectID
+	if projectID == 0 && wp.Spec.ProjectRef != "" {
+		id, err := resolveProjectID(ctx, op, config, wp.Spec.ProjectRef)
+		if err != nil {
+			return nil, r.markUnresolved(ctx, wp, "", v1alpha1.ReasonReferenceNotResolved, err, log)
+		}
+		projectID = id
+	}
+
+	typeID := wp.Spec.TypeID
+	if typeID == 0 && wp.Spec.TypeRef != "" {
+		id, err := resolveTypeID(ctx, op, config, projectID, wp.Spec.TypeRef)
+		if err != nil {
+			return nil, r.markUnresolved(ctx, wp, "", v1alpha1.ReasonReferenceNotResolved, err, log)
+		}
+		typeID = id
+	}
+
+	targets := &ticketTargets{TargetWorkPackageID: wp.Spec.TargetWorkPackageID}
+	if wp.Spec.Mode == ModeComment && targets.TargetWorkPackageID == 0 {
+		id, err := resolveWorkPackageRef(ctx, op, config, projectID, wp.Spec.TargetWorkPackageRef)
+		if err != nil {
+			return nil, r.markUnresolved(ctx, wp, "", v1alpha1.ReasonReferenceNotResolved, err, log)
+		}
+		targets.TargetWorkPackageID = id
+	}
+	if err := resolvePrincipals(ctx, op, config, &wp.Spec, targets); err != nil {
+		return nil, r.markUnresolved(ctx, wp, v1alpha1.ConditionPrincipalsResolved, v1alpha1.ReasonPrincipalNotFound, err, log)
+	}
+
+	update := WorkPackageStatusUpdate{}
+	if wp.Status.ResolvedProjectID != projectID || wp.Status.ResolvedTypeID != typeID {
+		statusLog(log, "🔎", "Resolved project and type", "projectID", projectID, "typeID", typeID)
+		update.ResolvedProjectID = projectID
+		update.ResolvedTypeID = typeID
+		if err := applyStatusUpdate(ctx, r, wp, update, log); err != nil {
+			log.Error(err, "❌ Failed to patch status")
+			return nil, err
+		}
+		update = WorkPackageStatusUpdate{}
+	}
+
+	// Custom fields are looked up in the schema of the resolved project and type
+	if err := resolveCustomFields(ctx, op, config, wp, targets); err != nil {
+		return nil, r.markUnresolved(ctx, wp, v1alpha1.ConditionCustomFieldsValid, v1alpha1.ReasonInvalidCustomField, err, log)
+	}
+
+	if hasPrincipals(wp) && !conditionCurrent(wp.Status.Conditions, v1alpha1.ConditionPrincipalsResolved, wp.Generation) {
+		update.Conditions = append(update.Conditions, newCondition(v1alpha1.ConditionPrincipalsResolved,
+			metav1.ConditionTrue, v1alpha1.ReasonPrincipalsResolved, "Assignee, responsible and watchers were found"))
+	}
+	if len(wp.Spec.CustomFields) > 0 && !conditionCurrent(wp.Status.Conditions, v1alpha1.ConditionCustomFieldsValid, wp.Generation) {
+		update.Conditions = append(update.Conditions, newCondition(v1alpha1.ConditionCustomFieldsValid,
+			metav1.ConditionTrue, v1alpha1.ReasonCustomFieldsResolved, "All custom fields matched the work package schema"))
+	}
+	if len(update.Conditions) == 0 {
+		return targets, nil
+	}
+
+	if err := applyStatusUpdate(ctx, r, wp, update, log); err != nil {
+		log.Error(err, "❌ Failed to patch status")
+		return nil, err
+	}
+	return targets, nil
+}
+
+// markUnresolved records a failed lookup on Ready and, when given, on a more specific condition
+func (r *WorkPackageReconciler) markUnresolved(ctx context.Context, wp *v1alpha1.WorkPackages, conditionType, reason string, cause error, log logr.Logger) error {
+	log.Error(cause, "❌ Failed to resolve ticket references")
+	r.Recorder.Event(wp, corev1.EventTypeWarning, reason, cause.Error())
+
+	conditions := []metav1.Condition{
+		newCondition(v1alpha1.ConditionReady, metav1.ConditionFalse, reason, cause.Error()),
+	}
+	if conditionType != "" {
+		conditions = append(conditions, newCondition(conditionType, metav1.ConditionFalse, reason, cause.Error()))
+	}
+	update := WorkPackageStatusUpdate{
+		Status:     StatusFailed,
+		Message:    cause.Error(),
+		Conditions: conditions,
+	}
+	if err := applyStatusUpdate(ctx, r, wp, update, log); err != nil {
+		log.Error(err, "❌ Failed to patch status")
+	}
+	return cause
+}
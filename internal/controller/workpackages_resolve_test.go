@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResolveTypeID(t *testing.T) {
+	responses := map[string]string{
+		"GET /projects/7/types": `{"total":1,"count":1,"_embedded":{"elements":[{"id":1,"name":"Task"}]}}`,
+		"GET /types":            `{"total":2,"count":2,"_embedded":{"elements":[{"id":1,"name":"Task"},{"id":2,"name":"Bug"}]}}`,
+	}
+
+	tests := []struct {
+		name    string
+		ref     string
+		want    int
+		wantErr string
+	}{
+		{name: "enabled type", ref: "task", want: 1},
+		{name: "type not enabled in the project", ref: "Bug", wantErr: `type "Bug" is not enabled in project 7`},
+		{name: "unknown type", ref: "Epic", wantErr: `type "Epic" not found`},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeOpenProject(t, responses)
+			// Each case gets its own cache scope
+			config := &v1alpha1.ServerConfig{ObjectMeta: metav1.ObjectMeta{Name: "types", Namespace: "test", Generation: int64(i + 1)}}
+
+			for attempt := 1; attempt <= 2; attempt++ {
+				got, err := resolveTypeID(context.Background(), f.client(), config, 7, tt.ref)
+				if tt.wantErr != "" {
+					if err == nil || err.Error() != tt.wantErr {
+						t.Fatalf("resolveTypeID() error = %v, want %q", err, tt.wantErr)
+					}
+				} else if err != nil || got != tt.want {
+					t.Fatalf("resolveTypeID() = %d, %v, want %d", got, err, tt.want)
+				}
+			}
+
+			// Only a resolved type is served from the cache the second time
+			wantRequests := 1
+			if tt.wantErr != "" {
+				wantRequests = 4
+			}
+			if n := len(f.received()); n != wantRequests {
+				t.Errorf("two lookups sent %d requests, want %d", n, wantRequests)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	"github.com/shrapk2/openproject-operator/internal/openproject"
+)
+
+// runKeyTimeFormat is the layout of the scheduled time embedded in a run key
+const runKeyTimeFormat = "20060102T1504Z"
+
+// ticketRun describes a single run that creates a ticket
+type ticketRun struct {
+	// Scheduled is the schedule slot, or the trigger time of a manual run
+	Scheduled time.Time
+	// RunKey identifies the run on the ticket
+	RunKey string
+	// RunNowToken is the run-now annotation value for manual runs
+	RunNowToken string
+}
+
+// manual reports whether the run was triggered by the run-now annotation
+func (r ticketRun) manual() bool {
+	return r.RunNowToken != ""
+}
+
+// newManualRun builds a run for a run-now annotation value; the key depends only on the value
+func newManualRun(wp *v1alpha1.WorkPackages, token string, loc *time.Location) ticketRun {
+	sum := sha256.Sum256([]byte(token))
+	return ticketRun{
+		Scheduled:   time.Now().In(loc),
+		RunKey:      fmt.Sprintf("%s-manual-%s", wp.UID, hex.EncodeToString(sum[:6])),
+		RunNowToken: token,
+	}
+}
+
+// buildRunKey returns the deterministic key identifying one scheduled run of a WorkPackages resource
+func buildRunKey(wp *v1alpha1.WorkPackages, scheduled time.Time) string {
+	return fmt.Sprintf("%s-%s", wp.UID, scheduled.UTC().Format(runKeyTimeFormat))
+}
+
+// runKeyMarker is appended to ticket descriptions so the run can be found again in OpenProject
+func runKeyMarker(runKey string) string {
+	return fmt.Sprintf("\n\n---\n_openproject-operator run key: `%s`_\n", runKey)
+}
+
+// findTicketByRunKey searches the project for a work package already created for the given run key;
+// nil when there is none
+func findTicketByRunKey(ctx context.Context, op *openproject.Client, projectID int, runKey string) (*openproject.WorkPackage, error) {
+	found, err := op.ListWorkPackages(ctx, openproject.ListOptions{
+		Filters: []openproject.Filter{
+			{Name: "project", Operator: "=", Values: []string{strconv.Itoa(projectID)}},
+			{Name: "status", Operator: "*"},
+			{Name: "description", Operator: "~", Values: []string{runKey}},
+		},
+		Limit: 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search work packages: %w", err)
+	}
+	if len(found) == 0 {
+		return nil, nil
+	}
+	return &found[0], nil
+}
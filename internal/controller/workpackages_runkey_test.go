@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuildRunKey(t *testing.T) {
+	berlin := time.FixedZone("CEST", 2*60*60)
+	wp := &v1alpha1.WorkPackages{ObjectMeta: metav1.ObjectMeta{UID: "8f0c"}}
+	other := &v1alpha1.WorkPackages{ObjectMeta: metav1.ObjectMeta{UID: "41d2"}}
+	slot := time.Date(2026, 10, 1, 7, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{name: "UTC slot", got: buildRunKey(wp, slot), want: "8f0c-20261001T0700Z"},
+		{name: "same slot in another zone", got: buildRunKey(wp, slot.In(berlin)), want: "8f0c-20261001T0700Z"},
+		{name: "seconds are ignored", got: buildRunKey(wp, slot.Add(42*time.Second)), want: "8f0c-20261001T0700Z"},
+		{name: "next slot", got: buildRunKey(wp, slot.Add(time.Hour)), want: "8f0c-20261001T0800Z"},
+		{name: "other resource", got: buildRunKey(other, slot), want: "41d2-20261001T0700Z"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: buildRunKey() = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+
+	if marker := runKeyMarker("8f0c-20261001T0700Z"); !strings.Contains(marker, "`8f0c-20261001T0700Z`") {
+		t.Errorf("runKeyMarker() = %q, want it to contain the run key", marker)
+	}
+}
+
+func TestNewManualRun(t *testing.T) {
+	wp := &v1alpha1.WorkPackages{ObjectMeta: metav1.ObjectMeta{UID: "8f0c"}}
+
+	first := newManualRun(wp, "2026-10-17T09:00:00Z", time.UTC)
+	again := newManualRun(wp, "2026-10-17T09:00:00Z", time.UTC)
+	other := newManualRun(wp, "2026-10-17T10:00:00Z", time.UTC)
+
+	if !strings.HasPrefix(first.RunKey, "8f0c-manual-") {
+		t.Errorf("newManualRun() key = %q, want prefix %q", first.RunKey, "8f0c-manual-")
+	}
+	if first.RunKey != again.RunKey {
+		t.Errorf("newManualRun() keys differ for the same token: %q, %q", first.RunKey, again.RunKey)
+	}
+	if first.RunKey == other.RunKey {
+		t.Errorf("newManualRun() key %q reused for another token", first.RunKey)
+	}
+	if !first.manual() || first.RunNowToken != "2026-10-17T09:00:00Z" {
+		t.Errorf("newManualRun() = %+v, want a manual run for the token", first)
+	}
+}
+
+func TestFindTicketByRunKey(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses map[string]string
+		want      int
+		wantErr   bool
+	}{
+		{
+			name: "ticket exists",
+			responses: map[string]string{
+				"GET /work_packages": `{"total":1,"count":1,"_embedded":{"elements":[{"id":42,"subject":"October Patch review"}]}}`,
+			},
+			want: 42,
+		},
+		{
+			name:      "no ticket",
+			responses: map[string]string{"GET /work_packages": `{"total":0,"count":0,"_embedded":{"elements":[]}}`},
+		},
+		{
+			name:    "search fails",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeOpenProject(t, tt.responses)
+
+			got, err := findTicketByRunKey(context.Background(), f.client(), 7, "8f0c-20261001T0700Z")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("findTicketByRunKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			switch {
+			case tt.want == 0 && got != nil:
+				t.Errorf("findTicketByRunKey() = #%d, want nil", got.ID)
+			case tt.want != 0 && (got == nil || got.ID != tt.want):
+				t.Errorf("findTicketByRunKey() = %v, want #%d", got, tt.want)
+			}
+
+			// The search covers closed tickets in the project and matches the key in the description
+			requests := f.received()
+			if len(requests) != 1 {
+				t.Fatalf("findTicketByRunKey() sent %d requests, want 1", len(requests))
+			}
+			var filters []map[string]struct {
+				Operator string   `json:"operator"`
+				Values   []string `json:"values"`
+			}
+			if err := json.Unmarshal([]byte(requests[0].Query.Get("filters")), &filters); err != nil {
+				t.Fatalf("invalid filters: %v", err)
+			}
+			want := map[string]string{"project": "=7", "status": "*", "description": "~8f0c-20261001T0700Z"}
+			for _, f := range filters {
+				for name, filter := range f {
+					if got := filter.Operator + strings.Join(filter.Values, ","); got != want[name] {
+						t.Errorf("filter %s = %q, want %q", name, got, want[name])
+					}
+					delete(want, name)
+				}
+			}
+			if len(want) > 0 {
+				t.Errorf("missing filters %v", want)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package controller
+
+import (
+	"time"
+
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+)
+
+// Constants for missed run policies
+const (
+	MissedRunSkip        = "skip"
+	MissedRunOnce        = "runOnce"
+	MissedRunBackfillAll = "backfillAll"
+)
+
+// Constants for concurrency policies
+const (
+	ConcurrencyForbid  = "Forbid"
+	ConcurrencyReplace = "Replace"
+)
+
+// maxDueRuns caps how many due schedule slots are enumerated, like CronJob's limit of 100
+const maxDueRuns = 100
+
+// runPlan is the scheduler's decision for a single reconcile
+type runPlan struct {
+	// Scheduled is the slot to run now, nil when nothing should run
+	Scheduled *time.Time
+	// Resumed is true when Scheduled belongs to the active run
+	Resumed bool
+	// Skipped counts slots passed over by the missed run or concurrency policy
+	Skipped int
+	// LastSkipped is the latest skipped slot, recorded as handled when nothing runs
+	LastSkipped *time.Time
+	// Next is the next slot after everything handled by this plan
+	Next time.Time
+}
+
+// scheduleBase returns the last schedule slot that was handled
+func scheduleBase(wp *v1alpha1.WorkPackages) time.Time {
+	if wp.Status.LastScheduleTime != nil {
+		return wp.Status.LastScheduleTime.Time
+	}
+	// Resources created before LastScheduleTime existed only tracked the actual run time
+	if wp.Status.LastRunTime != nil && !wp.Status.LastRunTime.IsZero() {
+		return wp.Status.LastRunTime.Time
+	}
+	return wp.CreationTimestamp.Time
+}
+
+// DefaultOnTimeTolerance is how late a slot may start under the skip policy without a starting deadline
+const DefaultOnTimeTolerance = 5 * time.Minute
+
+// onTimeTolerance is how late a slot may start and still count as on time under the skip policy
+func onTimeTolerance(wp *v1alpha1.WorkPackages) time.Duration {
+	if wp.Spec.StartingDeadlineSeconds != nil {
+		return time.Duration(*wp.Spec.StartingDeadlineSeconds) * time.Second
+	}
+	return DefaultOnTimeTolerance
+}
+
+// planRun applies the missed run, starting deadline and concurrency policies to the due schedule slots
+func planRun(wp *v1alpha1.WorkPackages, loc *time.Location, now time.Time) (runPlan, error) {
+	spec, err := parseSchedule(wp.Spec.Schedule)
+	if err != nil {
+		return runPlan{}, err
+	}
+
+	// Collect every slot that came due since the last handled one
+	var due []time.Time
+	t := spec.Next(scheduleBase(wp).In(loc))
+	for !t.After(now) && len(due) < maxDueRuns {
+		due = append(due, t)
+		t = spec.Next(t)
+	}
+
+	var plan runPlan
+	skip := func(slot time.Time) {
+		plan.Skipped++
+		s := slot
+		plan.LastSkipped = &s
+	}
+
+	expired := func(slot time.Time) bool {
+		return wp.Spec.StartingDeadlineSeconds != nil &&
+			now.Sub(slot) > time.Duration(*wp.Spec.StartingDeadlineSeconds)*time.Second
+	}
+
+	// A run that started but never completed takes precedence unless it is replaced or expired
+	if active := wp.Status.ActiveRun; active != nil {
+		activeSlot := active.ScheduledTime.In(loc)
+		var newer []time.Time
+		for _, slot := range due {
+			if slot.After(activeSlot) {
+				newer = append(newer, slot)
+			}
+		}
+
+		if !expired(activeSlot) && (wp.Spec.ConcurrencyPolicy != ConcurrencyReplace || len(newer) == 0) {
+			plan.Scheduled = &activeSlot
+			plan.Resumed = true
+			plan.Next = spec.Next(activeSlot)
+			return plan, nil
+		}
+
+		skip(activeSlot)
+		due = newer
+	}
+
+	// Slots past the starting deadline can no longer run
+	var runnable []time.Time
+	for _, slot := range due {
+		if expired(slot) {
+			skip(slot)
+		} else {
+			runnable = append(runnable, slot)
+		}
+	}
+
+	var chosen *time.Time
+	switch wp.Spec.MissedRunPolicy {
+	case MissedRunBackfillAll:
+		// Oldest first; the remaining slots are picked up by the following reconciles
+		if len(runnable) > 0 {
+			chosen = &runnable[0]
+		}
+	case MissedRunSkip:
+		// Only an on-time run with nothing missed before it
+		if len(runnable) == 1 && plan.Skipped == 0 && now.Sub(runnable[0]) <= onTimeTolerance(wp) {
+			chosen = &runnable[0]
+		} else {
+			for _, slot := range runnable {
+				skip(slot)
+			}
+		}
+	default:
+		// runOnce: a single run for the latest slot, however many were missed
+		for i, slot := range runnable {
+			if i == len(runnable)-1 {
+				chosen = &runnable[i]
+			} else {
+				skip(slot)
+			}
+		}
+	}
+
+	plan.Scheduled = chosen
+	switch {
+	case chosen != nil:
+		plan.Next = spec.Next(*chosen)
+	case plan.LastSkipped != nil:
+		plan.Next = spec.Next(*plan.LastSkipped)
+	default:
+		plan.Next = t
+	}
+	return plan, nil
+}
@@ -0,0 +1,216 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPlanRun(t *testing.T) {
+	at := func(clock string) time.Time {
+		ts, err := time.Parse(time.RFC3339, "2026-10-16T"+clock+":00Z")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ts
+	}
+	deadline := func(seconds int64) *int64 { return &seconds }
+	active := func(clock string) *v1alpha1.WorkPackageRun {
+		return &v1alpha1.WorkPackageRun{ScheduledTime: metav1.NewTime(at(clock))}
+	}
+
+	tests := []struct {
+		name        string
+		spec        v1alpha1.WorkPackagesSpec
+		activeRun   *v1alpha1.WorkPackageRun
+		now         string
+		want        string
+		wantResumed bool
+		wantSkipped int
+		wantNext    string
+	}{
+		{
+			name:     "nothing due",
+			now:      "10:30",
+			wantNext: "11:00",
+		},
+		{
+			name:     "on time",
+			now:      "11:00",
+			want:     "11:00",
+			wantNext: "12:00",
+		},
+		{
+			name:        "runOnce runs the latest missed slot",
+			spec:        v1alpha1.WorkPackagesSpec{MissedRunPolicy: MissedRunOnce},
+			now:         "13:10",
+			want:        "13:00",
+			wantSkipped: 2,
+			wantNext:    "14:00",
+		},
+		{
+			name:     "backfillAll runs the oldest missed slot",
+			spec:     v1alpha1.WorkPackagesSpec{MissedRunPolicy: MissedRunBackfillAll},
+			now:      "13:10",
+			want:     "11:00",
+			wantNext: "12:00",
+		},
+		{
+			name:     "skip runs a slot within the default tolerance",
+			spec:     v1alpha1.WorkPackagesSpec{MissedRunPolicy: MissedRunSkip},
+			now:      "11:04",
+			want:     "11:00",
+			wantNext: "12:00",
+		},
+		{
+			name:        "skip passes over a single late slot",
+			spec:        v1alpha1.WorkPackagesSpec{MissedRunPolicy: MissedRunSkip},
+			now:         "11:30",
+			wantSkipped: 1,
+			wantNext:    "12:00",
+		},
+		{
+			name:     "skip uses the starting deadline as tolerance",
+			spec:     v1alpha1.WorkPackagesSpec{MissedRunPolicy: MissedRunSkip, StartingDeadlineSeconds: deadline(3600)},
+			now:      "11:30",
+			want:     "11:00",
+			wantNext: "12:00",
+		},
+		{
+			name:        "skip passes over several missed slots",
+			spec:        v1alpha1.WorkPackagesSpec{MissedRunPolicy: MissedRunSkip},
+			now:         "13:02",
+			wantSkipped: 3,
+			wantNext:    "14:00",
+		},
+		{
+			name:        "starting deadline expires every slot",
+			spec:        v1alpha1.WorkPackagesSpec{MissedRunPolicy: MissedRunOnce, StartingDeadlineSeconds: deadline(600)},
+			now:         "13:30",
+			wantSkipped: 3,
+			wantNext:    "14:00",
+		},
+		{
+			name:        "starting deadline leaves the latest slot",
+			spec:        v1alpha1.WorkPackagesSpec{MissedRunPolicy: MissedRunBackfillAll, StartingDeadlineSeconds: deadline(600)},
+			now:         "13:05",
+			want:        "13:00",
+			wantSkipped: 2,
+			wantNext:    "14:00",
+		},
+		{
+			name:        "Forbid resumes the active run",
+			spec:        v1alpha1.WorkPackagesSpec{ConcurrencyPolicy: ConcurrencyForbid},
+			activeRun:   active("11:00"),
+			now:         "13:10",
+			want:        "11:00",
+			wantResumed: true,
+			wantNext:    "12:00",
+		},
+		{
+			name:        "Replace drops the active run for a newer slot",
+			spec:        v1alpha1.WorkPackagesSpec{ConcurrencyPolicy: ConcurrencyReplace},
+			activeRun:   active("11:00"),
+			now:         "13:10",
+			want:        "13:00",
+			wantSkipped: 2,
+			wantNext:    "14:00",
+		},
+		{
+			name:        "Replace resumes the active run when nothing newer is due",
+			spec:        v1alpha1.WorkPackagesSpec{ConcurrencyPolicy: ConcurrencyReplace},
+			activeRun:   active("11:00"),
+			now:         "11:30",
+			want:        "11:00",
+			wantResumed: true,
+			wantNext:    "12:00",
+		},
+		{
+			name:        "expired active run is dropped",
+			spec:        v1alpha1.WorkPackagesSpec{ConcurrencyPolicy: ConcurrencyForbid, StartingDeadlineSeconds: deadline(600)},
+			activeRun:   active("11:00"),
+			now:         "11:30",
+			wantSkipped: 1,
+			wantNext:    "12:00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.spec.Schedule = "0 * * * *"
+			wp := &v1alpha1.WorkPackages{
+				Spec: tt.spec,
+				Status: v1alpha1.WorkPackagesStatus{
+					LastScheduleTime: &metav1.Time{Time: at("10:00")},
+					ActiveRun:        tt.activeRun,
+				},
+			}
+
+			plan, err := planRun(wp, time.UTC, at(tt.now))
+			if err != nil {
+				t.Fatalf("planRun() error = %v", err)
+			}
+
+			switch {
+			case tt.want == "" && plan.Scheduled != nil:
+				t.Errorf("planRun() scheduled %s, want nothing", plan.Scheduled.Format(time.Kitchen))
+			case tt.want != "" && (plan.Scheduled == nil || !plan.Scheduled.Equal(at(tt.want))):
+				t.Errorf("planRun() scheduled %v, want %s", plan.Scheduled, tt.want)
+			}
+			if plan.Resumed != tt.wantResumed {
+				t.Errorf("planRun() resumed = %v, want %v", plan.Resumed, tt.wantResumed)
+			}
+			if plan.Skipped != tt.wantSkipped {
+				t.Errorf("planRun() skipped = %d, want %d", plan.Skipped, tt.wantSkipped)
+			}
+			if !plan.Next.Equal(at(tt.wantNext)) {
+				t.Errorf("planRun() next = %s, want %s", plan.Next.UTC().Format(time.RFC3339), tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestPlanRunScheduleBase(t *testing.T) {
+	created := metav1.NewTime(time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC))
+	lastRun := metav1.NewTime(time.Date(2026, 10, 16, 10, 5, 0, 0, time.UTC))
+	lastSlot := metav1.NewTime(time.Date(2026, 10, 16, 11, 0, 0, 0, time.UTC))
+	now := time.Date(2026, 10, 16, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		status v1alpha1.WorkPackagesStatus
+		want   time.Time
+	}{
+		{name: "last schedule time", status: v1alpha1.WorkPackagesStatus{LastScheduleTime: &lastSlot, LastRunTime: &lastRun}, want: lastSlot.Time},
+		{name: "last run time of older resources", status: v1alpha1.WorkPackagesStatus{LastRunTime: &lastRun}, want: lastRun.Time},
+		{name: "creation time", want: created.Time},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wp := &v1alpha1.WorkPackages{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created},
+				Spec:       v1alpha1.WorkPackagesSpec{Schedule: "0 * * * *", MissedRunPolicy: MissedRunBackfillAll},
+				Status:     tt.status,
+			}
+			if got := scheduleBase(wp); !got.Equal(tt.want) {
+				t.Errorf("scheduleBase() = %s, want %s", got, tt.want)
+			}
+
+			plan, err := planRun(wp, time.UTC, now)
+			if err != nil {
+				t.Fatalf("planRun() error = %v", err)
+			}
+			if want := tt.want.Truncate(time.Hour).Add(time.Hour); plan.Scheduled == nil || !plan.Scheduled.Equal(want) {
+				t.Errorf("planRun() scheduled %v, want %s", plan.Scheduled, want)
+			}
+		})
+	}
+
+	wp := &v1alpha1.WorkPackages{Spec: v1alpha1.WorkPackagesSpec{Schedule: "not a schedule"}}
+	if _, err := planRun(wp, time.UTC, now); err == nil {
+		t.Error("planRun() with an invalid schedule returned no error")
+	}
+}
@@ -0,0 +1,153 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestReconcileSuspendAndRunNow(t *testing.T) {
+	next := metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+
+	tests := []struct {
+		name        string
+		suspend     bool
+		annotation  string
+		lastHandled string
+		wantWrites  []string
+		wantStatus  string
+		wantHandled string
+	}{
+		{
+			name:       "suspended skips due run",
+			suspend:    true,
+			wantStatus: StatusSuspended,
+		},
+		{
+			name:        "run-now while suspended",
+			suspend:     true,
+			annotation:  "2026-10-17T09:00:00Z",
+			wantWrites:  []string{"POST /work_packages"},
+			wantStatus:  StatusCreated,
+			wantHandled: "2026-10-17T09:00:00Z",
+		},
+		{
+			name:        "handled run-now is not repeated",
+			suspend:     true,
+			annotation:  "2026-10-17T09:00:00Z",
+			lastHandled: "2026-10-17T09:00:00Z",
+			wantStatus:  StatusSuspended,
+			wantHandled: "2026-10-17T09:00:00Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeOpenProject(t, map[string]string{
+				"GET /work_packages":  `{"total":0,"count":0,"_embedded":{"elements":[]}}`,
+				"POST /work_packages": `{"id":42}`,
+			})
+			config, secret := testServerConfig("default", f.URL)
+			wp := &v1alpha1.WorkPackages{
+				ObjectMeta: metav1.ObjectMeta{Name: "patching", Namespace: "default", UID: "8f0c"},
+				Spec: v1alpha1.WorkPackagesSpec{
+					Schedule:        "0 7 * * *",
+					Subject:         "Patch review",
+					ProjectID:       7,
+					TypeID:          1,
+					Suspend:         tt.suspend,
+					ServerConfigRef: v1alpha1.ServerConfigRef{Name: config.Name},
+				},
+				Status: v1alpha1.WorkPackagesStatus{
+					Status:            StatusScheduled,
+					NextRunTime:       &next,
+					LastRunTime:       &metav1.Time{},
+					LastHandledRunNow: tt.lastHandled,
+				},
+			}
+			if tt.annotation != "" {
+				wp.Annotations = map[string]string{v1alpha1.RunNowAnnotation: tt.annotation}
+			}
+			r := newTestWorkPackageReconciler(t, wp, config, secret)
+
+			key := types.NamespacedName{Name: wp.Name, Namespace: wp.Namespace}
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			if writes := f.writes(); !slices.Equal(writes, tt.wantWrites) {
+				t.Errorf("Reconcile() writes = %v, want %v", writes, tt.wantWrites)
+			}
+			var got v1alpha1.WorkPackages
+			if err := r.Get(context.Background(), key, &got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Status.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", got.Status.Status, tt.wantStatus)
+			}
+			if got.Status.LastHandledRunNow != tt.wantHandled {
+				t.Errorf("lastHandledRunNow = %q, want %q", got.Status.LastHandledRunNow, tt.wantHandled)
+			}
+			// Manual runs leave the schedule untouched
+			if got.Status.NextRunTime == nil || !got.Status.NextRunTime.Equal(&next) {
+				t.Errorf("nextRunTime = %v, want %v", got.Status.NextRunTime, next)
+			}
+		})
+	}
+}
+
+func TestReconcileRunsSkipped(t *testing.T) {
+	f := newFakeOpenProject(t, map[string]string{
+		"GET /work_packages":  `{"total":0,"count":0,"_embedded":{"elements":[]}}`,
+		"POST /work_packages": `{"id":42}`,
+	})
+	config, secret := testServerConfig("default", f.URL)
+	// Three hourly slots came due since the last one was handled
+	last := metav1.NewTime(time.Now().Truncate(time.Hour).Add(-3 * time.Hour))
+	next := metav1.NewTime(last.Add(time.Hour))
+	wp := &v1alpha1.WorkPackages{
+		ObjectMeta: metav1.ObjectMeta{Name: "patching", Namespace: "default", UID: "8f0c"},
+		Spec: v1alpha1.WorkPackagesSpec{
+			Schedule:        "0 * * * *",
+			TimeZone:        "UTC",
+			Subject:         "Patch review",
+			ProjectID:       7,
+			TypeID:          1,
+			ServerConfigRef: v1alpha1.ServerConfigRef{Name: config.Name},
+		},
+		Status: v1alpha1.WorkPackagesStatus{
+			Status:           StatusScheduled,
+			NextRunTime:      &next,
+			LastScheduleTime: &last,
+		},
+	}
+	r := newTestWorkPackageReconciler(t, wp, config, secret)
+
+	key := types.NamespacedName{Name: wp.Name, Namespace: wp.Namespace}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	if writes := f.writes(); !slices.Equal(writes, []string{"POST /work_packages"}) {
+		t.Errorf("Reconcile() writes = %v, want the latest slot created", writes)
+	}
+	var got v1alpha1.WorkPackages
+	if err := r.Get(context.Background(), key, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.TimeZone != "UTC" {
+		t.Errorf("status.timeZone = %q, want UTC", got.Status.TimeZone)
+	}
+	events := recordedEvents(r.Recorder)
+	want := "Normal " + v1alpha1.ReasonRunsSkipped + " Skipped 2 missed run(s) under the runOnce policy"
+	if len(events) == 0 || !strings.HasPrefix(events[0], want) {
+		t.Errorf("events = %v, want %q first", events, want)
+	}
+}
@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"context"
+	"slices"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+)
+
+// workPackagesForConfig maps a ServerConfig or ClusterServerConfig to the WorkPackages using it
+func (r *WorkPackageReconciler) workPackagesForConfig(kind string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		requests, err := workPackagesUsing(ctx, r.Client, serverConfigKey(kind, obj.GetNamespace(), obj.GetName()))
+		if err != nil {
+			ctrl.Log.Error(err, "❌ Unable to list WorkPackages", "kind", kind, "object", obj.GetName())
+			return nil
+		}
+		return requests
+	}
+}
+
+// workPackagesForCredentials maps a Secret or ConfigMap to the WorkPackages whose config reads it
+func (r *WorkPackageReconciler) workPackagesForCredentials(field string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		keys, err := serverConfigsReading(ctx, r.Client, field, obj)
+		if err != nil {
+			ctrl.Log.Error(err, "❌ Unable to list ServerConfigs", "object", obj.GetName())
+			return nil
+		}
+		requests, err := workPackagesUsing(ctx, r.Client, keys...)
+		if err != nil {
+			ctrl.Log.Error(err, "❌ Unable to list WorkPackages", "object", obj.GetName())
+			return nil
+		}
+		return requests
+	}
+}
+
+// workPackagesForGrant maps a ServerConfigGrant to the WorkPackages of other namespaces using the
+// ServerConfigs it may cover, so that a granted or revoked reference is picked up
+func (r *WorkPackageReconciler) workPackagesForGrant(ctx context.Context, obj client.Object) []reconcile.Request {
+	grant := obj.(*v1alpha1.ServerConfigGrant)
+	var configs v1alpha1.ServerConfigList
+	if err := r.List(ctx, &configs, client.InNamespace(grant.Namespace)); err != nil {
+		ctrl.Log.Error(err, "❌ Unable to list ServerConfigs", "grant", grant.Name)
+		return nil
+	}
+
+	var keys []string
+	for _, config := range configs.Items {
+		if len(grant.Spec.ServerConfigNames) == 0 || slices.Contains(grant.Spec.ServerConfigNames, config.Name) {
+			keys = append(keys, serverConfigKey(v1alpha1.ServerConfigKind, config.Namespace, config.Name))
+		}
+	}
+	requests, err := workPackagesUsing(ctx, r.Client, keys...)
+	if err != nil {
+		ctrl.Log.Error(err, "❌ Unable to list WorkPackages", "grant", grant.Name)
+		return nil
+	}
+	return slices.DeleteFunc(requests, func(req reconcile.Request) bool {
+		return req.Namespace == grant.Namespace
+	})
+}
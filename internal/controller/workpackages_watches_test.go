@@ -0,0 +1,141 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	v1alpha1 "github.com/shrapk2/openproject-operator/api/v1alpha1"
+	"github.com/shrapk2/openproject-operator/internal/configloader"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestWorkPackagesWatchMapping(t *testing.T) {
+	secretRef := func(name string) corev1.SecretKeySelector {
+		return corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Key: "apiKey"}
+	}
+	workPackages := func(namespace, name string, ref v1alpha1.ServerConfigRef) *v1alpha1.WorkPackages {
+		return &v1alpha1.WorkPackages{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       v1alpha1.WorkPackagesSpec{ServerConfigRef: ref},
+		}
+	}
+
+	objs := []client.Object{
+		&v1alpha1.ServerConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "openproject", Namespace: "team-a"},
+			Spec: v1alpha1.ServerConfigSpec{
+				APIKeySecretRef: secretRef("openproject"),
+				TLS: &v1alpha1.ServerTLS{CABundle: &v1alpha1.CABundleSource{ConfigMapRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "ca"},
+					Key:                  "ca.crt",
+				}}},
+			},
+		},
+		&v1alpha1.ServerConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "team-a"},
+			Spec:       v1alpha1.ServerConfigSpec{APIKeySecretRef: secretRef("other")},
+		},
+		&v1alpha1.ClusterServerConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared"},
+			Spec: v1alpha1.ClusterServerConfigSpec{
+				ServerConfigSpec:     v1alpha1.ServerConfigSpec{APIKeySecretRef: secretRef("openproject")},
+				CredentialsNamespace: "platform",
+			},
+		},
+		workPackages("team-a", "local", v1alpha1.ServerConfigRef{Name: "openproject"}),
+		workPackages("team-b", "borrowed", v1alpha1.ServerConfigRef{Name: "openproject", Namespace: "team-a"}),
+		workPackages("team-a", "unrelated", v1alpha1.ServerConfigRef{Name: "other"}),
+		workPackages("team-c", "cluster", v1alpha1.ServerConfigRef{Name: "shared", Kind: v1alpha1.ClusterServerConfigKind}),
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(newTestScheme(t)).
+		WithObjects(objs...).
+		WithIndex(&v1alpha1.WorkPackages{}, IndexFieldServerConfigRef, func(obj client.Object) []string {
+			return []string{workPackagesServerConfigKey(obj.(*v1alpha1.WorkPackages))}
+		}).
+		WithIndex(&v1alpha1.ServerConfig{}, IndexFieldSecretNames, func(obj client.Object) []string {
+			return configloader.SecretNames(obj.(*v1alpha1.ServerConfig))
+		}).
+		WithIndex(&v1alpha1.ServerConfig{}, IndexFieldConfigMapNames, func(obj client.Object) []string {
+			return configloader.ConfigMapNames(obj.(*v1alpha1.ServerConfig))
+		}).
+		WithIndex(&v1alpha1.ClusterServerConfig{}, IndexFieldSecretNames, func(obj client.Object) []string {
+			cluster := obj.(*v1alpha1.ClusterServerConfig)
+			return inNamespace(cluster.Spec.CredentialsNamespace, configloader.SecretNames(configloader.ServerConfigView(cluster)))
+		}).
+		WithIndex(&v1alpha1.ClusterServerConfig{}, IndexFieldConfigMapNames, func(obj client.Object) []string {
+			cluster := obj.(*v1alpha1.ClusterServerConfig)
+			return inNamespace(cluster.Spec.CredentialsNamespace, configloader.ConfigMapNames(configloader.ServerConfigView(cluster)))
+		}).
+		Build()
+	r := &WorkPackageReconciler{Client: c}
+	ctx := context.Background()
+	meta := func(namespace, name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: namespace}
+	}
+
+	tests := []struct {
+		name string
+		got  []reconcile.Request
+		want []string
+	}{
+		{
+			name: "ServerConfig",
+			got:  r.workPackagesForConfig(v1alpha1.ServerConfigKind)(ctx, &v1alpha1.ServerConfig{ObjectMeta: meta("team-a", "openproject")}),
+			want: []string{"team-a/local", "team-b/borrowed"},
+		},
+		{
+			name: "ClusterServerConfig",
+			got:  r.workPackagesForConfig(v1alpha1.ClusterServerConfigKind)(ctx, &v1alpha1.ClusterServerConfig{ObjectMeta: meta("", "shared")}),
+			want: []string{"team-c/cluster"},
+		},
+		{
+			name: "Secret of a ServerConfig",
+			got:  r.workPackagesForCredentials(IndexFieldSecretNames)(ctx, &corev1.Secret{ObjectMeta: meta("team-a", "openproject")}),
+			want: []string{"team-a/local", "team-b/borrowed"},
+		},
+		{
+			name: "Secret in the credentials namespace of a ClusterServerConfig",
+			got:  r.workPackagesForCredentials(IndexFieldSecretNames)(ctx, &corev1.Secret{ObjectMeta: meta("platform", "openproject")}),
+			want: []string{"team-c/cluster"},
+		},
+		{
+			name: "ConfigMap",
+			got:  r.workPackagesForCredentials(IndexFieldConfigMapNames)(ctx, &corev1.ConfigMap{ObjectMeta: meta("team-a", "ca")}),
+			want: []string{"team-a/local", "team-b/borrowed"},
+		},
+		{
+			name: "unreferenced Secret",
+			got:  r.workPackagesForCredentials(IndexFieldSecretNames)(ctx, &corev1.Secret{ObjectMeta: meta("team-b", "openproject")}),
+		},
+		{
+			name: "grant covering every ServerConfig",
+			got:  r.workPackagesForGrant(ctx, &v1alpha1.ServerConfigGrant{ObjectMeta: meta("team-a", "all")}),
+			want: []string{"team-b/borrowed"},
+		},
+		{
+			name: "grant for another ServerConfig",
+			got: r.workPackagesForGrant(ctx, &v1alpha1.ServerConfigGrant{
+				ObjectMeta: meta("team-a", "other"),
+				Spec:       v1alpha1.ServerConfigGrantSpec{ServerConfigNames: []string{"other"}},
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for _, req := range tt.got {
+			got = append(got, req.String())
+		}
+		slices.Sort(got)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: requests = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package openproject
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+)
+
+// Credentials authenticate requests to the API
+type Credentials interface {
+	// Authorization returns the value of the Authorization header
+	Authorization(ctx context.Context) (string, error)
+}
+
+// APIKey authenticates with a user's API key using Basic auth
+type APIKey string
+
+// Authorization implements Credentials
+func (k APIKey) Authorization(context.Context) (string, error) {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte("apikey:"+string(k))), nil
+}
+
+// BearerToken authenticates with OAuth2 access tokens from a token source
+type BearerToken struct {
+	Source oauth2.TokenSource
+}
+
+// Authorization implements Credentials
+func (b BearerToken) Authorization(context.Context) (string, error) {
+	token, err := b.Source.Token()
+	if err != nil {
+		return "", fmt.Errorf("failed to get OAuth2 token: %w", err)
+	}
+	return "Bearer " + token.AccessToken, nil
+}
+
+// credentialsError wraps a failure to authenticate. A token endpoint that rejected the client
+// is reported as 401 so it is not retried like a network error.
+func credentialsError(method, path string, err error) *Error {
+	apiErr := &Error{Method: method, Path: path, Err: err}
+	var retrieveErr *oauth2.RetrieveError
+	if errors.As(err, &retrieveErr) && retrieveErr.Response != nil && retrieveErr.Response.StatusCode < 500 {
+		apiErr.StatusCode = http.StatusUnauthorized
+	}
+	return apiErr
+}
@@ -0,0 +1,185 @@
+// Package openproject is a small client for the OpenProject API v3.
+package openproject
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// apiPrefix is the path of the API v3 root
+const apiPrefix = "/api/v3"
+
+// Client talks to a single OpenProject server
+type Client struct {
+	// BaseURL is the server URL without the API path, e.g. https://openproject.example.com
+	BaseURL string
+	// Credentials authenticate each request
+	Credentials Credentials
+	// HTTPClient sends the requests; http.DefaultClient when nil
+	HTTPClient *http.Client
+	// Timeout bounds each attempt; no extra bound when zero
+	Timeout time.Duration
+	// Retry controls how failed requests are retried
+	Retry RetryPolicy
+}
+
+// NewClient returns a client for the server at baseURL
+func NewClient(baseURL string, credentials Credentials, httpClient *http.Client, timeout time.Duration) *Client {
+	return &Client{
+		BaseURL:     strings.TrimRight(baseURL, "/"),
+		Credentials: credentials,
+		HTTPClient:  httpClient,
+		Timeout:     timeout,
+		Retry:       DefaultRetryPolicy,
+	}
+}
+
+// WorkPackageURL returns the browser URL of a work package
+func (c *Client) WorkPackageURL(id int) string {
+	return fmt.Sprintf("%s/work_packages/%d", c.BaseURL, id)
+}
+
+// newRequest builds an authenticated request for an API path such as /work_packages
+func (c *Client) newRequest(ctx context.Context, method, path string, query url.Values, body io.Reader, contentType string) (*http.Request, error) {
+	u := c.BaseURL + apiPrefix + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.Credentials != nil {
+		authorization, err := c.Credentials.Authorization(ctx)
+		if err != nil {
+			return nil, credentialsError(method, path, err)
+		}
+		req.Header.Set("Authorization", authorization)
+	}
+	req.Header.Set("Accept", "application/hal+json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return req, nil
+}
+
+// send executes a request, retrying transient failures of replayable requests per the retry
+// policy, and decodes a successful JSON response into out. Failures are returned as *Error.
+func (c *Client) send(req *http.Request, out interface{}) error {
+	maxAttempts := c.Retry.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		countAttempt(req.Context())
+		err := c.sendOnce(req, out)
+		if err == nil {
+			return nil
+		}
+
+		var apiErr *Error
+		if !errors.As(err, &apiErr) {
+			return err
+		}
+		apiErr.Attempts = attempt
+		if attempt >= maxAttempts || !IsTransient(err) || !replayable(req.Method, err) || req.Context().Err() != nil {
+			return err
+		}
+
+		delay := c.Retry.backoff(attempt)
+		if apiErr.RetryAfter > 0 {
+			if apiErr.RetryAfter > c.Retry.MaxDelay {
+				return err
+			}
+			delay = apiErr.RetryAfter
+		}
+
+		// The body is rewound for the next attempt; requests without GetBody cannot be replayed
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return err
+			}
+			req.Body = body
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// sendOnce executes a single attempt of a request
+func (c *Client) sendOnce(req *http.Request, out interface{}) error {
+	if c.Timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), c.Timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return &Error{Method: req.Method, Path: req.URL.Path, Err: err}
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= 300 {
+		apiErr := parseError(req.Method, req.URL.Path, resp.StatusCode, data)
+		apiErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+		return apiErr
+	}
+
+	if out != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return nil
+}
+
+// do sends a JSON request to an API path and decodes the response into out
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, payload, out interface{}) error {
+	var body io.Reader
+	contentType := ""
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal payload: %w", err)
+		}
+		body = bytes.NewReader(data)
+		contentType = "application/json"
+	}
+
+	req, err := c.newRequest(ctx, method, path, query, body, contentType)
+	if err != nil {
+		return err
+	}
+	return c.send(req, out)
+}
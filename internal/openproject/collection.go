@@ -0,0 +1,105 @@
+package openproject
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// defaultPageSize is the page size requested when listing collections
+const defaultPageSize = 100
+
+// maxPages bounds how many pages a single list call fetches
+const maxPages = 50
+
+// Filter is a single API filter, e.g. {"status": {"operator": "o", "values": []}}
+type Filter struct {
+	Name     string
+	Operator string
+	Values   []string
+}
+
+// Filters encodes filters as the JSON the API expects in the filters query parameter
+func Filters(filters ...Filter) (string, error) {
+	encoded := make([]map[string]interface{}, 0, len(filters))
+	for _, f := range filters {
+		values := f.Values
+		if values == nil {
+			values = []string{}
+		}
+		encoded = append(encoded, map[string]interface{}{
+			f.Name: map[string]interface{}{"operator": f.Operator, "values": values},
+		})
+	}
+	data, err := json.Marshal(encoded)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal filters: %w", err)
+	}
+	return string(data), nil
+}
+
+// collection is a page of a HAL collection
+type collection[T any] struct {
+	Total    int `json:"total"`
+	Count    int `json:"count"`
+	PageSize int `json:"pageSize"`
+	Offset   int `json:"offset"`
+	Embedded struct {
+		Elements []T `json:"elements"`
+	} `json:"_embedded"`
+}
+
+// ListOptions narrows a list call
+type ListOptions struct {
+	Filters []Filter
+	// Limit stops after this many elements; all pages are fetched when zero
+	Limit int
+}
+
+// query returns the query parameters for one page
+func (o ListOptions) query(offset, pageSize int) (url.Values, error) {
+	q := url.Values{}
+	if len(o.Filters) > 0 {
+		filters, err := Filters(o.Filters...)
+		if err != nil {
+			return nil, err
+		}
+		q.Set("filters", filters)
+	}
+	q.Set("offset", strconv.Itoa(offset))
+	q.Set("pageSize", strconv.Itoa(pageSize))
+	return q, nil
+}
+
+// list fetches the elements of a collection, following offset pagination
+func list[T any](ctx context.Context, c *Client, path string, opts ListOptions) ([]T, error) {
+	pageSize := defaultPageSize
+	if opts.Limit > 0 && opts.Limit < pageSize {
+		pageSize = opts.Limit
+	}
+
+	var all []T
+	// The API numbers pages from 1
+	for page := 1; page <= maxPages; page++ {
+		q, err := opts.query(page, pageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		var result collection[T]
+		if err := c.do(ctx, "GET", path, q, nil, &result); err != nil {
+			return nil, err
+		}
+		all = append(all, result.Embedded.Elements...)
+
+		if opts.Limit > 0 && len(all) >= opts.Limit {
+			return all[:opts.Limit], nil
+		}
+		if len(result.Embedded.Elements) == 0 || len(all) >= result.Total {
+			return all, nil
+		}
+	}
+	return all, nil
+}
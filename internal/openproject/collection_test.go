@@ -0,0 +1,128 @@
+package openproject
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// elementsPage returns a collection page body holding the given ids
+func elementsPage(total int, ids ...int) string {
+	elements := make([]string, 0, len(ids))
+	for _, id := range ids {
+		elements = append(elements, fmt.Sprintf(`{"id":%d}`, id))
+	}
+	return fmt.Sprintf(`{"total":%d,"count":%d,"_embedded":{"elements":[%s]}}`, total, len(ids), strings.Join(elements, ","))
+}
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		name string
+		opts ListOptions
+		// page returns the body served for a 1-based page, or "" for a server error
+		page         func(page int) string
+		wantIDs      int
+		wantRequests int32
+		wantErr      bool
+	}{
+		{
+			name:         "single page",
+			page:         func(int) string { return elementsPage(2, 1, 2) },
+			wantIDs:      2,
+			wantRequests: 1,
+		},
+		{
+			name:         "pages until total",
+			page:         func(p int) string { return elementsPage(5, 2*p-1, 2*p) },
+			wantIDs:      6,
+			wantRequests: 3,
+		},
+		{
+			name: "empty page ends the listing",
+			page: func(p int) string {
+				if p > 1 {
+					return elementsPage(10)
+				}
+				return elementsPage(10, 1, 2)
+			},
+			wantIDs:      2,
+			wantRequests: 2,
+		},
+		{
+			name:         "limit stops early",
+			opts:         ListOptions{Limit: 3},
+			page:         func(p int) string { return elementsPage(100, 2*p-1, 2*p) },
+			wantIDs:      3,
+			wantRequests: 2,
+		},
+		{
+			name:         "page count is capped",
+			page:         func(p int) string { return elementsPage(1000, p) },
+			wantIDs:      maxPages,
+			wantRequests: maxPages,
+		},
+		{
+			name: "error on a later page",
+			page: func(p int) string {
+				if p > 1 {
+					return ""
+				}
+				return elementsPage(4, 1, 2)
+			},
+			wantRequests: 2,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				requests.Add(1)
+				page, err := strconv.Atoi(req.URL.Query().Get("offset"))
+				if err != nil || page < 1 {
+					t.Errorf("invalid offset %q", req.URL.Query().Get("offset"))
+				}
+				body := tt.page(page)
+				if body == "" {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				_, _ = w.Write([]byte(body))
+			}))
+			defer server.Close()
+			c := NewClient(server.URL, APIKey("secret"), server.Client(), 0)
+			c.Retry = RetryPolicy{MaxAttempts: 1}
+
+			got, err := list[WorkPackage](context.Background(), c, "/work_packages", tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("list() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != tt.wantIDs {
+				t.Errorf("list() returned %d elements, want %d", len(got), tt.wantIDs)
+			}
+			if n := requests.Load(); n != tt.wantRequests {
+				t.Errorf("list() sent %d requests, want %d", n, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestListOptionsQuery(t *testing.T) {
+	opts := ListOptions{Filters: []Filter{{Name: "status", Operator: "o"}}}
+	q, err := opts.query(2, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := q.Get("filters"), `[{"status":{"operator":"o","values":[]}}]`; got != want {
+		t.Errorf("filters = %s, want %s", got, want)
+	}
+	if q.Get("offset") != "2" || q.Get("pageSize") != "50" {
+		t.Errorf("offset = %q, pageSize = %q, want 2 and 50", q.Get("offset"), q.Get("pageSize"))
+	}
+}
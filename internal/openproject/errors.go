@@ -0,0 +1,110 @@
+package openproject
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Error is a failed request: a non-2xx response from the API, parsed from its HAL error body
+// when there is one, or a request that got no response, with StatusCode 0 and Err set
+type Error struct {
+	Method     string
+	Path       string
+	StatusCode int
+	// Identifier is the errorIdentifier URN, e.g. urn:openproject-org:api:v3:errors:PropertyConstraintViolation
+	Identifier string
+	// Message is the human readable message, joined for multiple errors
+	Message string
+	// Err is the transport error when the server was not reached
+	Err error
+	// Attempts is how many times the request was sent
+	Attempts int
+	// RetryAfter is the wait requested by the server's Retry-After header
+	RetryAfter time.Duration
+}
+
+// Error implements error
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s %s: %v", e.Method, e.Path, e.Err)
+	}
+	msg := fmt.Sprintf("%s %s returned status %d", e.Method, e.Path, e.StatusCode)
+	if name := e.Name(); name != "" {
+		msg += " " + name
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// Unwrap returns the transport error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Name returns the last segment of the error identifier, e.g. PropertyConstraintViolation
+func (e *Error) Name() string {
+	if i := strings.LastIndex(e.Identifier, ":"); i >= 0 {
+		return e.Identifier[i+1:]
+	}
+	return e.Identifier
+}
+
+// halError is the body of an API error response
+type halError struct {
+	ErrorIdentifier string `json:"errorIdentifier"`
+	Message         string `json:"message"`
+	Embedded        struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	} `json:"_embedded"`
+}
+
+// parseError builds an *Error from a response body, falling back to the status text
+func parseError(method, path string, status int, body []byte) *Error {
+	e := &Error{Method: method, Path: path, StatusCode: status}
+
+	var he halError
+	if err := json.Unmarshal(body, &he); err != nil || (he.ErrorIdentifier == "" && he.Message == "") {
+		e.Message = http.StatusText(status)
+		return e
+	}
+
+	e.Identifier = he.ErrorIdentifier
+	e.Message = he.Message
+	// MultipleErrors carries the individual violations in _embedded.errors
+	if len(he.Embedded.Errors) > 0 {
+		msgs := make([]string, 0, len(he.Embedded.Errors))
+		for _, sub := range he.Embedded.Errors {
+			msgs = append(msgs, sub.Message)
+		}
+		e.Message = strings.Join(msgs, "; ")
+	}
+	return e
+}
+
+// StatusCode returns the HTTP status of an API error, or 0 when the server was not reached
+func StatusCode(err error) int {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsNotFound reports whether err is a 404 from the API
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+// IsUnauthorized reports whether err means the credentials were rejected
+func IsUnauthorized(err error) bool {
+	code := StatusCode(err)
+	return code == http.StatusUnauthorized || code == http.StatusForbidden
+}
@@ -0,0 +1,82 @@
+package openproject
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		wantName     string
+		wantMessage  string
+		notFound     bool
+		unauthorized bool
+	}{
+		{
+			name:        "HAL error",
+			status:      http.StatusUnprocessableEntity,
+			body:        `{"_type":"Error","errorIdentifier":"urn:openproject-org:api:v3:errors:PropertyConstraintViolation","message":"Subject can't be blank."}`,
+			wantName:    "PropertyConstraintViolation",
+			wantMessage: "Subject can't be blank.",
+		},
+		{
+			name:   "multiple errors",
+			status: http.StatusUnprocessableEntity,
+			body: `{"_type":"Error","errorIdentifier":"urn:openproject-org:api:v3:errors:MultipleErrors","message":"Multiple field constraints have been violated.",` +
+				`"_embedded":{"errors":[{"message":"Subject can't be blank."},{"message":"Type is not set to one of the allowed values."}]}}`,
+			wantName:    "MultipleErrors",
+			wantMessage: "Subject can't be blank.; Type is not set to one of the allowed values.",
+		},
+		{
+			name:        "not found",
+			status:      http.StatusNotFound,
+			body:        `{"_type":"Error","errorIdentifier":"urn:openproject-org:api:v3:errors:NotFound","message":"The requested resource could not be found."}`,
+			wantName:    "NotFound",
+			wantMessage: "The requested resource could not be found.",
+			notFound:    true,
+		},
+		{
+			name:         "HTML body",
+			status:       http.StatusForbidden,
+			body:         `<html><body>Forbidden</body></html>`,
+			wantMessage:  "Forbidden",
+			unauthorized: true,
+		},
+		{
+			name:         "empty body",
+			status:       http.StatusUnauthorized,
+			wantMessage:  "Unauthorized",
+			unauthorized: true,
+		},
+		{
+			name:        "JSON without error fields",
+			status:      http.StatusBadGateway,
+			body:        `{"status":"down"}`,
+			wantMessage: "Bad Gateway",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := parseError("POST", "/api/v3/work_packages", tt.status, []byte(tt.body))
+			if e.StatusCode != tt.status || e.Name() != tt.wantName || e.Message != tt.wantMessage {
+				t.Errorf("parseError() = %d %q %q, want %d %q %q", e.StatusCode, e.Name(), e.Message, tt.status, tt.wantName, tt.wantMessage)
+			}
+
+			// The helpers see through wrapping
+			err := fmt.Errorf("create ticket: %w", e)
+			if StatusCode(err) != tt.status || IsNotFound(err) != tt.notFound || IsUnauthorized(err) != tt.unauthorized {
+				t.Errorf("StatusCode() = %d, IsNotFound() = %v, IsUnauthorized() = %v", StatusCode(err), IsNotFound(err), IsUnauthorized(err))
+			}
+		})
+	}
+
+	if StatusCode(errors.New("connection refused")) != 0 {
+		t.Error("StatusCode() of a network error should be 0")
+	}
+}
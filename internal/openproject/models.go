@@ -0,0 +1,272 @@
+package openproject
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Link is a HAL link
+type Link struct {
+	Href   string `json:"href,omitempty"`
+	Title  string `json:"title,omitempty"`
+	Method string `json:"method,omitempty"`
+}
+
+// ID returns the numeric ID at the end of the link's href, or 0
+func (l *Link) ID() int {
+	if l == nil || l.Href == "" {
+		return 0
+	}
+	id, err := strconv.Atoi(l.Href[strings.LastIndex(l.Href, "/")+1:])
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// Href returns the API path of a resource, e.g. Href("projects", 4) is /api/v3/projects/4
+func Href(resource string, id int) string {
+	return fmt.Sprintf("%s/%s/%d", apiPrefix, resource, id)
+}
+
+// LinkTo returns a link to a resource by ID
+func LinkTo(resource string, id int) *Link {
+	return &Link{Href: Href(resource, id)}
+}
+
+// Formattable is a text property with markup
+type Formattable struct {
+	Format string `json:"format,omitempty"`
+	Raw    string `json:"raw"`
+	HTML   string `json:"html,omitempty"`
+}
+
+// Markdown returns a markdown formattable
+func Markdown(raw string) *Formattable {
+	return &Formattable{Format: "markdown", Raw: raw}
+}
+
+// WorkPackageLinks are the links of a work package
+type WorkPackageLinks struct {
+	Self        *Link `json:"self,omitempty"`
+	Project     *Link `json:"project,omitempty"`
+	Type        *Link `json:"type,omitempty"`
+	Status      *Link `json:"status,omitempty"`
+	Parent      *Link `json:"parent,omitempty"`
+	Assignee    *Link `json:"assignee,omitempty"`
+	Responsible *Link `json:"responsible,omitempty"`
+	Version     *Link `json:"version,omitempty"`
+}
+
+// WorkPackage is a work package resource
+type WorkPackage struct {
+	ID          int              `json:"id,omitempty"`
+	Subject     string           `json:"subject,omitempty"`
+	Description *Formattable     `json:"description,omitempty"`
+	LockVersion int              `json:"lockVersion"`
+	Links       WorkPackageLinks `json:"_links"`
+}
+
+// Project is a project resource
+type Project struct {
+	ID         int    `json:"id"`
+	Identifier string `json:"identifier"`
+	Name       string `json:"name"`
+	Active     bool   `json:"active"`
+}
+
+// Type is a work package type
+type Type struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Status is a work package status
+type Status struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	IsClosed bool   `json:"isClosed"`
+}
+
+// User is a user resource
+type User struct {
+	ID     int    `json:"id"`
+	Login  string `json:"login"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	Status string `json:"status"`
+}
+
+// PrincipalLinks are the links of a principal
+type PrincipalLinks struct {
+	Self *Link `json:"self,omitempty"`
+}
+
+// Principal is a user, group or placeholder user, distinguished by Type
+type Principal struct {
+	Type  string         `json:"_type"`
+	ID    int            `json:"id"`
+	Name  string         `json:"name"`
+	Login string         `json:"login,omitempty"`
+	Email string         `json:"email,omitempty"`
+	Links PrincipalLinks `json:"_links"`
+}
+
+// Principal types
+const (
+	PrincipalUser  = "User"
+	PrincipalGroup = "Group"
+)
+
+// Version is a project version
+type Version struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// AttachmentLinks are the links of an attachment
+type AttachmentLinks struct {
+	DownloadLocation *Link `json:"downloadLocation,omitempty"`
+}
+
+// Attachment is a file attached to a resource
+type Attachment struct {
+	ID          int             `json:"id"`
+	FileName    string          `json:"fileName"`
+	FileSize    int64           `json:"fileSize"`
+	ContentType string          `json:"contentType"`
+	Links       AttachmentLinks `json:"_links"`
+}
+
+// Activity is an entry in a work package's activity stream
+type Activity struct {
+	ID      int          `json:"id"`
+	Comment *Formattable `json:"comment,omitempty"`
+}
+
+// RelationLinks are the endpoints of a relation
+type RelationLinks struct {
+	From *Link `json:"from,omitempty"`
+	To   *Link `json:"to,omitempty"`
+}
+
+// Relation connects two work packages
+type Relation struct {
+	ID    int           `json:"id,omitempty"`
+	Type  string        `json:"type"`
+	Links RelationLinks `json:"_links"`
+}
+
+// reverseRelationTypes maps the relation types OpenProject stores reversed to their canonical type;
+// "a follows b" is returned as "b precedes a"
+var reverseRelationTypes = map[string]string{
+	"follows":    "precedes",
+	"blocked":    "blocks",
+	"duplicated": "duplicates",
+	"partof":     "includes",
+	"required":   "requires",
+}
+
+// canonicalRelation returns a relation in the type and direction OpenProject stores it in
+func canonicalRelation(relationType string, from, to int) (string, int, int) {
+	if canonical, ok := reverseRelationTypes[relationType]; ok {
+		return canonical, to, from
+	}
+	return relationType, from, to
+}
+
+// Connects reports whether the relation is a relation of the given type from a to b. Reverse
+// types match their canonical counterpart in the opposite direction, and relates matches
+// in either direction.
+func (r *Relation) Connects(relationType string, a, b int) bool {
+	wantType, wantFrom, wantTo := canonicalRelation(relationType, a, b)
+	gotType, gotFrom, gotTo := canonicalRelation(r.Type, r.Links.From.ID(), r.Links.To.ID())
+	if gotType != wantType {
+		return false
+	}
+	if gotFrom == wantFrom && gotTo == wantTo {
+		return true
+	}
+	return wantType == "relates" && gotFrom == wantTo && gotTo == wantFrom
+}
+
+// SchemaField describes one attribute of a work package schema
+type SchemaField struct {
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Required bool   `json:"required"`
+	Writable bool   `json:"writable"`
+	// AllowedValues lists the options of list fields; other fields link to a collection instead
+	AllowedValues []Link `json:"-"`
+}
+
+// Schema is a work package schema for one project and type, keyed by attribute
+type Schema struct {
+	Fields map[string]SchemaField
+}
+
+// UnmarshalJSON keeps the attribute entries of a schema and their embedded allowed values
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	s.Fields = map[string]SchemaField{}
+	for key, value := range raw {
+		if strings.HasPrefix(key, "_") {
+			continue
+		}
+		var field struct {
+			SchemaField
+			Links struct {
+				AllowedValues json.RawMessage `json:"allowedValues"`
+			} `json:"_links"`
+		}
+		if err := json.Unmarshal(value, &field); err != nil || field.Type == "" {
+			continue
+		}
+		// A single link points to a collection rather than listing the options
+		var options []Link
+		if json.Unmarshal(field.Links.AllowedValues, &options) == nil {
+			field.SchemaField.AllowedValues = options
+		}
+		s.Fields[key] = field.SchemaField
+	}
+	return nil
+}
+
+// CustomField finds a custom field by its display name and returns its attribute key
+func (s *Schema) CustomField(name string) (string, SchemaField, bool) {
+	for key, field := range s.Fields {
+		if strings.HasPrefix(key, "customField") && strings.EqualFold(field.Name, name) {
+			return key, field, true
+		}
+	}
+	return "", SchemaField{}, false
+}
+
+// FormError is a validation error of one attribute in a form
+type FormError struct {
+	ErrorIdentifier string `json:"errorIdentifier"`
+	Message         string `json:"message"`
+}
+
+// Form is the result of validating a payload with a form endpoint
+type Form struct {
+	Embedded struct {
+		// ValidationErrors is keyed by attribute; empty when the payload is valid
+		ValidationErrors map[string]FormError `json:"validationErrors"`
+	} `json:"_embedded"`
+}
+
+// Root is the API root, used to check connectivity
+type Root struct {
+	InstanceName    string `json:"instanceName"`
+	CoreVersion     string `json:"coreVersion"`
+	CoreSHA         string `json:"coreSHA"`
+	CoreVersionName string `json:"coreVersionName"`
+}
@@ -0,0 +1,233 @@
+package openproject
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"strconv"
+)
+
+// Root fetches the API root
+func (c *Client) Root(ctx context.Context) (*Root, error) {
+	var root Root
+	if err := c.do(ctx, "GET", "", nil, nil, &root); err != nil {
+		return nil, err
+	}
+	return &root, nil
+}
+
+// CurrentUser fetches the user the API key belongs to
+func (c *Client) CurrentUser(ctx context.Context) (*User, error) {
+	var user User
+	if err := c.do(ctx, "GET", "/users/me", nil, nil, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetWorkPackage fetches a work package by ID
+func (c *Client) GetWorkPackage(ctx context.Context, id int) (*WorkPackage, error) {
+	var wp WorkPackage
+	if err := c.do(ctx, "GET", fmt.Sprintf("/work_packages/%d", id), nil, nil, &wp); err != nil {
+		return nil, err
+	}
+	return &wp, nil
+}
+
+// CreateWorkPackage creates a work package. The payload is usually a map so that
+// arbitrary extra properties can be merged into it.
+func (c *Client) CreateWorkPackage(ctx context.Context, payload interface{}) (*WorkPackage, error) {
+	var wp WorkPackage
+	if err := c.do(ctx, "POST", "/work_packages", nil, payload, &wp); err != nil {
+		return nil, err
+	}
+	return &wp, nil
+}
+
+// UpdateWorkPackage patches a work package; the payload must carry the current lockVersion
+func (c *Client) UpdateWorkPackage(ctx context.Context, id int, payload interface{}) (*WorkPackage, error) {
+	var wp WorkPackage
+	if err := c.do(ctx, "PATCH", fmt.Sprintf("/work_packages/%d", id), nil, payload, &wp); err != nil {
+		return nil, err
+	}
+	return &wp, nil
+}
+
+// ValidateWorkPackage checks a create payload with the work package form of a project, or the
+// global form when projectID is 0, without creating anything
+func (c *Client) ValidateWorkPackage(ctx context.Context, projectID int, payload interface{}) (*Form, error) {
+	path := "/work_packages/form"
+	if projectID != 0 {
+		path = fmt.Sprintf("/projects/%d/work_packages/form", projectID)
+	}
+	var form Form
+	if err := c.do(ctx, "POST", path, nil, payload, &form); err != nil {
+		return nil, err
+	}
+	return &form, nil
+}
+
+// GetWorkPackageSchema fetches the schema of work packages of a type in a project
+func (c *Client) GetWorkPackageSchema(ctx context.Context, projectID, typeID int) (*Schema, error) {
+	var schema Schema
+	if err := c.do(ctx, "GET", fmt.Sprintf("/work_packages/schemas/%d-%d", projectID, typeID), nil, nil, &schema); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// ListWorkPackages lists work packages across all projects
+func (c *Client) ListWorkPackages(ctx context.Context, opts ListOptions) ([]WorkPackage, error) {
+	return list[WorkPackage](ctx, c, "/work_packages", opts)
+}
+
+// SetWorkPackageStatus changes the status of a work package, using its current lock version
+func (c *Client) SetWorkPackageStatus(ctx context.Context, id, statusID int) error {
+	current, err := c.GetWorkPackage(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	update := WorkPackage{
+		LockVersion: current.LockVersion,
+		Links:       WorkPackageLinks{Status: LinkTo("statuses", statusID)},
+	}
+	_, err = c.UpdateWorkPackage(ctx, id, update)
+	return err
+}
+
+// AddComment adds a markdown comment to a work package's activity stream
+func (c *Client) AddComment(ctx context.Context, id int, comment string) (*Activity, error) {
+	payload := Activity{Comment: &Formattable{Raw: comment}}
+	var activity Activity
+	if err := c.do(ctx, "POST", fmt.Sprintf("/work_packages/%d/activities", id), nil, payload, &activity); err != nil {
+		return nil, err
+	}
+	return &activity, nil
+}
+
+// ListActivities lists the activity stream of a work package, oldest first
+func (c *Client) ListActivities(ctx context.Context, id int) ([]Activity, error) {
+	return list[Activity](ctx, c, fmt.Sprintf("/work_packages/%d/activities", id), ListOptions{})
+}
+
+// CreateRelation creates a relation of the given type from one work package to another
+func (c *Client) CreateRelation(ctx context.Context, fromID, toID int, relationType string) (*Relation, error) {
+	payload := Relation{
+		Type:  relationType,
+		Links: RelationLinks{To: LinkTo("work_packages", toID)},
+	}
+	var relation Relation
+	if err := c.do(ctx, "POST", fmt.Sprintf("/work_packages/%d/relations", fromID), nil, payload, &relation); err != nil {
+		return nil, err
+	}
+	return &relation, nil
+}
+
+// ListRelations lists the relations a work package is part of, in either direction
+func (c *Client) ListRelations(ctx context.Context, workPackageID int) ([]Relation, error) {
+	return list[Relation](ctx, c, fmt.Sprintf("/work_packages/%d/relations", workPackageID), ListOptions{})
+}
+
+// ListAttachments lists the attachments of a work package
+func (c *Client) ListAttachments(ctx context.Context, workPackageID int) ([]Attachment, error) {
+	return list[Attachment](ctx, c, fmt.Sprintf("/work_packages/%d/attachments", workPackageID), ListOptions{})
+}
+
+// GetProject fetches a project by numeric ID or identifier
+func (c *Client) GetProject(ctx context.Context, idOrIdentifier string) (*Project, error) {
+	var project Project
+	if err := c.do(ctx, "GET", "/projects/"+idOrIdentifier, nil, nil, &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// ListProjects lists the projects visible to the user
+func (c *Client) ListProjects(ctx context.Context, opts ListOptions) ([]Project, error) {
+	return list[Project](ctx, c, "/projects", opts)
+}
+
+// ListTypes lists all work package types
+func (c *Client) ListTypes(ctx context.Context) ([]Type, error) {
+	return list[Type](ctx, c, "/types", ListOptions{})
+}
+
+// ListProjectTypes lists the work package types enabled in a project
+func (c *Client) ListProjectTypes(ctx context.Context, projectID int) ([]Type, error) {
+	return list[Type](ctx, c, fmt.Sprintf("/projects/%d/types", projectID), ListOptions{})
+}
+
+// ListStatuses lists all work package statuses
+func (c *Client) ListStatuses(ctx context.Context) ([]Status, error) {
+	return list[Status](ctx, c, "/statuses", ListOptions{})
+}
+
+// ListUsers lists users; listing requires admin permissions on most instances
+func (c *Client) ListUsers(ctx context.Context, opts ListOptions) ([]User, error) {
+	return list[User](ctx, c, "/users", opts)
+}
+
+// ListPrincipals lists users, groups and placeholder users
+func (c *Client) ListPrincipals(ctx context.Context, opts ListOptions) ([]Principal, error) {
+	return list[Principal](ctx, c, "/principals", opts)
+}
+
+// AddWatcher adds a user as a watcher of a work package
+func (c *Client) AddWatcher(ctx context.Context, workPackageID, userID int) error {
+	payload := map[string]*Link{"user": LinkTo("users", userID)}
+	return c.do(ctx, "POST", fmt.Sprintf("/work_packages/%d/watchers", workPackageID), nil, payload, nil)
+}
+
+// ListWatchers lists the users watching a work package
+func (c *Client) ListWatchers(ctx context.Context, workPackageID int) ([]User, error) {
+	return list[User](ctx, c, fmt.Sprintf("/work_packages/%d/watchers", workPackageID), ListOptions{})
+}
+
+// ListProjectVersions lists the versions available in a project
+func (c *Client) ListProjectVersions(ctx context.Context, projectID int) ([]Version, error) {
+	return list[Version](ctx, c, "/projects/"+strconv.Itoa(projectID)+"/versions", ListOptions{})
+}
+
+// UploadAttachment attaches a file to a work package
+func (c *Client) UploadAttachment(ctx context.Context, workPackageID int, fileName, contentType string, content []byte) (*Attachment, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	metadata, err := json.Marshal(map[string]string{"fileName": fileName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal attachment metadata: %w", err)
+	}
+	if err := writer.WriteField("metadata", string(metadata)); err != nil {
+		return nil, fmt.Errorf("failed to write attachment metadata: %w", err)
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename=%q`, fileName))
+	header.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create attachment part: %w", err)
+	}
+	if _, err := part.Write(content); err != nil {
+		return nil, fmt.Errorf("failed to write attachment content: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish attachment body: %w", err)
+	}
+
+	path := fmt.Sprintf("/work_packages/%d/attachments", workPackageID)
+	req, err := c.newRequest(ctx, "POST", path, nil, &body, writer.FormDataContentType())
+	if err != nil {
+		return nil, err
+	}
+
+	var attachment Attachment
+	if err := c.send(req, &attachment); err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
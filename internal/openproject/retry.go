@@ -0,0 +1,145 @@
+package openproject
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried. Network errors, 429 and 5xx
+// responses are retried; other 4xx responses fail on the first attempt. POST requests are
+// only retried on 429, see replayable.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts; one attempt when below 1
+	MaxAttempts int
+	// BaseDelay is the wait before the second attempt, doubled for each further attempt
+	BaseDelay time.Duration
+	// MaxDelay caps the wait between attempts. A longer Retry-After is not waited for;
+	// the error is returned with RetryAfter set instead.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy is used by NewClient
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 4, BaseDelay: time.Second, MaxDelay: 30 * time.Second}
+
+// backoff returns the jittered wait after the given attempt, between half and all of the
+// exponential delay
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	half := int64(delay / 2)
+	if half <= 0 {
+		return delay
+	}
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+// Error categories reported by Category
+const (
+	CategoryNetwork      = "Network"
+	CategoryRateLimited  = "RateLimited"
+	CategoryServer       = "ServerError"
+	CategoryUnauthorized = "Unauthorized"
+	CategoryNotFound     = "NotFound"
+	CategoryValidation   = "Validation"
+	CategoryClient       = "ClientError"
+)
+
+// Category classifies an error returned by the client; empty for nil
+func Category(err error) string {
+	if err == nil {
+		return ""
+	}
+	code := StatusCode(err)
+	switch {
+	case code == 0:
+		return CategoryNetwork
+	case code == http.StatusTooManyRequests:
+		return CategoryRateLimited
+	case code >= 500:
+		return CategoryServer
+	case code == http.StatusUnauthorized || code == http.StatusForbidden:
+		return CategoryUnauthorized
+	case code == http.StatusNotFound:
+		return CategoryNotFound
+	case code == http.StatusBadRequest || code == http.StatusUnprocessableEntity:
+		return CategoryValidation
+	default:
+		return CategoryClient
+	}
+}
+
+// IsTransient reports whether a request that failed with err may succeed when repeated
+func IsTransient(err error) bool {
+	switch Category(err) {
+	case CategoryNetwork, CategoryRateLimited, CategoryServer:
+		return true
+	}
+	return false
+}
+
+// replayable reports whether a request that failed with a transient error may be sent again.
+// A POST creates something, and after a network error or 5xx the server may already have
+// committed it, so it is only repeated when the server turned it away with 429. The same holds
+// for a PATCH: once applied, its lockVersion is stale and the replay fails with a conflict.
+func replayable(method string, err error) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return Category(err) == CategoryRateLimited
+}
+
+// Attempts returns how many attempts a failed request made, or 0 when unknown
+func Attempts(err error) int {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Attempts
+	}
+	return 0
+}
+
+// RetryAfter returns the wait the server asked for, or 0
+func RetryAfter(err error) time.Duration {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.RetryAfter
+	}
+	return 0
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(t); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
+
+// attemptCounterKey is the context key of an attempt counter
+type attemptCounterKey struct{}
+
+// WithAttemptCounter returns a context in which every request attempt increments *n
+func WithAttemptCounter(ctx context.Context, n *int) context.Context {
+	return context.WithValue(ctx, attemptCounterKey{}, n)
+}
+
+// countAttempt increments the context's attempt counter, if any
+func countAttempt(ctx context.Context) {
+	if n, ok := ctx.Value(attemptCounterKey{}).(*int); ok {
+		*n++
+	}
+}
@@ -0,0 +1,217 @@
+package openproject
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	tests := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{attempt: 1, max: 100 * time.Millisecond},
+		{attempt: 2, max: 200 * time.Millisecond},
+		{attempt: 3, max: 400 * time.Millisecond},
+		{attempt: 4, max: 800 * time.Millisecond},
+		{attempt: 5, max: time.Second},
+		{attempt: 64, max: time.Second},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 50; i++ {
+			got := policy.backoff(tt.attempt)
+			if got < tt.max/2 || got > tt.max {
+				t.Fatalf("backoff(%d) = %s, want between %s and %s", tt.attempt, got, tt.max/2, tt.max)
+			}
+		}
+	}
+}
+
+func TestCategory(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		want      string
+		transient bool
+	}{
+		{name: "nil", err: nil, want: ""},
+		{name: "network", err: &Error{Err: errors.New("connection refused")}, want: CategoryNetwork, transient: true},
+		{name: "rate limited", err: &Error{StatusCode: 429}, want: CategoryRateLimited, transient: true},
+		{name: "bad gateway", err: &Error{StatusCode: 502}, want: CategoryServer, transient: true},
+		{name: "unavailable", err: &Error{StatusCode: 503}, want: CategoryServer, transient: true},
+		{name: "unauthorized", err: &Error{StatusCode: 401}, want: CategoryUnauthorized},
+		{name: "forbidden", err: &Error{StatusCode: 403}, want: CategoryUnauthorized},
+		{name: "not found", err: &Error{StatusCode: 404}, want: CategoryNotFound},
+		{name: "bad request", err: &Error{StatusCode: 400}, want: CategoryValidation},
+		{name: "unprocessable", err: &Error{StatusCode: 422}, want: CategoryValidation},
+		{name: "conflict", err: &Error{StatusCode: 409}, want: CategoryClient},
+		{name: "wrapped", err: errors.Join(errors.New("create ticket"), &Error{StatusCode: 503}), want: CategoryServer, transient: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Category(tt.err); got != tt.want {
+				t.Errorf("Category() = %q, want %q", got, tt.want)
+			}
+			if got := IsTransient(tt.err); got != tt.transient {
+				t.Errorf("IsTransient() = %v, want %v", got, tt.transient)
+			}
+		})
+	}
+}
+
+func TestReplayable(t *testing.T) {
+	tests := []struct {
+		method string
+		status int
+		want   bool
+	}{
+		{method: http.MethodGet, status: 503, want: true},
+		{method: http.MethodGet, status: 0, want: true},
+		{method: http.MethodPatch, status: 502, want: false},
+		{method: http.MethodPatch, status: 429, want: true},
+		{method: http.MethodDelete, status: 503, want: true},
+		{method: http.MethodPost, status: 429, want: true},
+		{method: http.MethodPost, status: 502, want: false},
+		{method: http.MethodPost, status: 504, want: false},
+		{method: http.MethodPost, status: 0, want: false},
+	}
+
+	for _, tt := range tests {
+		err := &Error{StatusCode: tt.status}
+		if tt.status == 0 {
+			err.Err = errors.New("connection reset")
+		}
+		if got := replayable(tt.method, err); got != tt.want {
+			t.Errorf("replayable(%s, %d) = %v, want %v", tt.method, tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		min   time.Duration
+		max   time.Duration
+	}{
+		{value: "", min: 0, max: 0},
+		{value: "7", min: 7 * time.Second, max: 7 * time.Second},
+		{value: "-3", min: 0, max: 0},
+		{value: "soon", min: 0, max: 0},
+		{value: time.Now().Add(time.Minute).UTC().Format(http.TimeFormat), min: 58 * time.Second, max: time.Minute},
+		{value: time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), min: 0, max: 0},
+	}
+
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value); got < tt.min || got > tt.max {
+			t.Errorf("parseRetryAfter(%q) = %s, want between %s and %s", tt.value, got, tt.min, tt.max)
+		}
+	}
+}
+
+func TestSendRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		statuses     []int
+		retryAfter   string
+		wantAttempts int
+		wantStatus   int
+	}{
+		{
+			name:         "GET is retried until it succeeds",
+			method:       http.MethodGet,
+			statuses:     []int{503, 502, 200},
+			wantAttempts: 3,
+		},
+		{
+			name:         "GET gives up after the last attempt",
+			method:       http.MethodGet,
+			statuses:     []int{503, 503, 503, 503},
+			wantAttempts: 3,
+			wantStatus:   503,
+		},
+		{
+			name:         "PATCH is not replayed after a server error",
+			method:       http.MethodPatch,
+			statuses:     []int{502, 200},
+			wantAttempts: 1,
+			wantStatus:   502,
+		},
+		{
+			name:         "POST is not replayed after a server error",
+			method:       http.MethodPost,
+			statuses:     []int{502, 200},
+			wantAttempts: 1,
+			wantStatus:   502,
+		},
+		{
+			name:         "POST is retried when rate limited",
+			method:       http.MethodPost,
+			statuses:     []int{429, 201},
+			retryAfter:   "0",
+			wantAttempts: 2,
+		},
+		{
+			name:         "validation errors fail fast",
+			method:       http.MethodPatch,
+			statuses:     []int{422, 200},
+			wantAttempts: 1,
+			wantStatus:   422,
+		},
+		{
+			name:         "Retry-After beyond the maximum delay is not waited for",
+			method:       http.MethodGet,
+			statuses:     []int{429, 200},
+			retryAfter:   "120",
+			wantAttempts: 1,
+			wantStatus:   429,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				n := int(calls.Add(1))
+				status := tt.statuses[min(n, len(tt.statuses))-1]
+				if status == http.StatusTooManyRequests && tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(status)
+				_, _ = w.Write([]byte(`{"id":1}`))
+			}))
+			defer server.Close()
+
+			c := NewClient(server.URL, APIKey("secret"), server.Client(), 0)
+			c.Retry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+			var payload interface{}
+			if tt.method != http.MethodGet {
+				payload = map[string]string{"subject": "Patch review"}
+			}
+
+			counted := 0
+			err := c.do(WithAttemptCounter(context.Background(), &counted), tt.method, "/work_packages", nil, payload, nil)
+			if got := StatusCode(err); got != tt.wantStatus {
+				t.Fatalf("do() error = %v, want status %d", err, tt.wantStatus)
+			}
+			if got := int(calls.Load()); got != tt.wantAttempts {
+				t.Errorf("server got %d requests, want %d", got, tt.wantAttempts)
+			}
+			if counted != tt.wantAttempts {
+				t.Errorf("attempt counter = %d, want %d", counted, tt.wantAttempts)
+			}
+			if err != nil && Attempts(err) != tt.wantAttempts {
+				t.Errorf("Attempts() = %d, want %d", Attempts(err), tt.wantAttempts)
+			}
+		})
+	}
+}